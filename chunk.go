@@ -0,0 +1,156 @@
+package spnr
+
+import (
+	"reflect"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+)
+
+// ChunkPolicy is the limits used to split large writes into chunks by the XXXChunked methods.
+// Zero value of each limit means no limit.
+// A record exceeding the limits by itself is written as a chunk of one record.
+type ChunkPolicy struct {
+	// MaxMutationCells is the max number of cells (columns x rows) written in one commit by Mutation.
+	// Note that Spanner also counts the cells of secondary indexes, so leave some margin for them.
+	MaxMutationCells int
+	// MaxParams is the max number of params used in one statement by DML.
+	// It limits the records in the multi-row INSERT and DELETE statements of DML.InsertChunked and DML.DeleteChunked.
+	MaxParams int
+	// MaxStatements is the max number of statements executed in one batch by DML.UpdateChunked, which executes a statement for each record.
+	MaxStatements int
+	// MaxBytes is the max estimated size of the values written in one chunk.
+	MaxBytes int
+	// ContinueOnError makes the remaining chunks be written even if a chunk fails.
+	// By default, the remaining chunks are skipped after the first failure.
+	ContinueOnError bool
+}
+
+// DefaultChunkPolicy is used when Options.ChunkPolicy is not specified.
+// It has a margin against the limits of Spanner (80,000 mutations per commit, 950 params per statement and 100MB per commit).
+// MaxStatements keeps a batch of update statements in a moderate size, since Spanner doesn't limit the number explicitly.
+var DefaultChunkPolicy = ChunkPolicy{
+	MaxMutationCells: 40000,
+	MaxParams:        900,
+	MaxStatements:    1000,
+	MaxBytes:         32 << 20,
+}
+
+// ChunkResult is the result of a chunk written by the XXXChunked methods.
+// The chunk contains the records target[Offset:Offset+Len].
+type ChunkResult struct {
+	Offset int
+	Len    int
+	// CommitTimestamp is set when the chunk is committed in its own transaction.
+	CommitTimestamp time.Time
+	// RowCount is the number of rows modified by the statement (DML only).
+	RowCount int64
+	Err      error
+}
+
+type chunkCost struct {
+	cells      int
+	params     int
+	statements int
+	bytes      int
+}
+
+type chunk struct {
+	offset int
+	len    int
+}
+
+// splitChunks splits n records into chunks so that each chunk doesn't exceed the limits of the policy.
+func splitChunks(n int, cost func(i int) chunkCost, p ChunkPolicy) []chunk {
+	var chunks []chunk
+	var cur chunk
+	var total chunkCost
+	for i := 0; i < n; i++ {
+		c := cost(i)
+		if cur.len > 0 && (exceeds(total.cells+c.cells, p.MaxMutationCells) ||
+			exceeds(total.params+c.params, p.MaxParams) ||
+			exceeds(total.statements+c.statements, p.MaxStatements) ||
+			exceeds(total.bytes+c.bytes, p.MaxBytes)) {
+			chunks = append(chunks, cur)
+			cur = chunk{offset: i}
+			total = chunkCost{}
+		}
+		cur.len++
+		total.cells += c.cells
+		total.params += c.params
+		total.statements += c.statements
+		total.bytes += c.bytes
+	}
+	if cur.len > 0 {
+		chunks = append(chunks, cur)
+	}
+	return chunks
+}
+
+func exceeds(v, limit int) bool {
+	return limit > 0 && v > limit
+}
+
+// firstChunkErr returns the first error in the results.
+func firstChunkErr(results []ChunkResult) error {
+	for _, r := range results {
+		if r.Err != nil {
+			return r.Err
+		}
+	}
+	return nil
+}
+
+// estimateSize returns the rough size in bytes of the value sent to spanner.
+func estimateSize(v any) int {
+	switch vv := v.(type) {
+	case nil:
+		return 0
+	case string:
+		return len(vv)
+	case []byte:
+		return len(vv)
+	case spanner.NullString:
+		return len(vv.StringVal)
+	case time.Time, spanner.NullTime:
+		return 12
+	case civil.Date, spanner.NullDate:
+		return 4
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return 0
+		}
+		return estimateSize(rv.Elem().Interface())
+	case reflect.String:
+		return rv.Len()
+	case reflect.Slice:
+		var size int
+		for i := 0; i < rv.Len(); i++ {
+			size += estimateSize(rv.Index(i).Interface())
+		}
+		return size
+	case reflect.Bool:
+		return 1
+	}
+	return 8
+}
+
+func estimateValuesSize(values []any) int {
+	var size int
+	for _, v := range values {
+		size += estimateSize(v)
+	}
+	return size
+}
+
+// subSlice returns the pointer of target[offset:offset+length] for the pointer of slice target.
+func subSlice(target any, offset, length int) any {
+	slice := reflect.ValueOf(target).Elem()
+	sub := reflect.New(slice.Type())
+	sub.Elem().Set(slice.Slice(offset, offset+length))
+	return sub.Interface()
+}
@@ -0,0 +1,125 @@
+package spnr
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitChunks(t *testing.T) {
+	cost := func(i int) chunkCost {
+		return chunkCost{cells: 10, params: 2, statements: 1, bytes: 100}
+	}
+	tests := []struct {
+		name   string
+		n      int
+		policy ChunkPolicy
+		want   []chunk
+	}{
+		{name: "no records", n: 0, policy: DefaultChunkPolicy, want: nil},
+		{name: "no limit", n: 5, policy: ChunkPolicy{}, want: []chunk{{offset: 0, len: 5}}},
+		{name: "cells", n: 5, policy: ChunkPolicy{MaxMutationCells: 20}, want: []chunk{{offset: 0, len: 2}, {offset: 2, len: 2}, {offset: 4, len: 1}}},
+		{name: "params", n: 5, policy: ChunkPolicy{MaxParams: 7}, want: []chunk{{offset: 0, len: 3}, {offset: 3, len: 2}}},
+		{name: "statements", n: 5, policy: ChunkPolicy{MaxStatements: 2}, want: []chunk{{offset: 0, len: 2}, {offset: 2, len: 2}, {offset: 4, len: 1}}},
+		{name: "bytes", n: 3, policy: ChunkPolicy{MaxBytes: 150}, want: []chunk{{offset: 0, len: 1}, {offset: 1, len: 1}, {offset: 2, len: 1}}},
+		{name: "record exceeding limit", n: 2, policy: ChunkPolicy{MaxMutationCells: 5}, want: []chunk{{offset: 0, len: 1}, {offset: 1, len: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitChunks(tt.n, cost, tt.policy))
+		})
+	}
+}
+
+func TestEstimateSize(t *testing.T) {
+	assert.Equal(t, 3, estimateSize("abc"))
+	assert.Equal(t, 4, estimateSize([]byte("abcd")))
+	assert.Equal(t, 5, estimateSize(spanner.NullString{StringVal: "abcde", Valid: true}))
+	assert.Equal(t, 8, estimateSize(int64(1)))
+	assert.Equal(t, 6, estimateSize([]string{"abc", "def"}))
+	assert.Equal(t, 0, estimateSize((*string)(nil)))
+}
+
+func TestMutation_splitChunks(t *testing.T) {
+	m := NewMutationWithOptions("Test", &Options{ChunkPolicy: &ChunkPolicy{MaxMutationCells: 50}})
+	s := []Test{*testRecord1, *testRecord2, *testRecord3}
+	// Test has 23 columns
+	assert.Equal(t, []chunk{{offset: 0, len: 2}, {offset: 2, len: 1}}, m.splitChunks(toStructSlice(&s), false, m.getChunkPolicy()))
+	assert.Equal(t, []chunk{{offset: 0, len: 3}}, m.splitChunks(toStructSlice(&s), true, m.getChunkPolicy()))
+}
+
+func TestDML_buildDeleteChunkStmt(t *testing.T) {
+	d := NewDMLWithOptions("Test", &Options{ChunkPolicy: &ChunkPolicy{MaxParams: 5}})
+	s := []*Test{testRecord1, testRecord2, testRecord3}
+	chunks := d.splitChunks(&s, false, chunkDelete, d.getChunkPolicy())
+	// Test has 2 pk columns
+	assert.Equal(t, []chunk{{offset: 0, len: 2}, {offset: 2, len: 1}}, chunks)
+
+	stmt := d.buildDeleteChunkStmt(&s, false, chunks[1])
+	assert.Equal(t, "DELETE FROM `Test` WHERE (`String`=@w_String_0 AND `Int64`=@w_Int64_0)", stmt.SQL)
+	assert.Equal(t, testRecord3.String, stmt.Params["w_String_0"])
+}
+
+func TestMutation_ApplyInsertOrUpdateChunked(t *testing.T) {
+	ctx := context.Background()
+	m := NewMutationWithOptions("Test", &Options{ChunkPolicy: &ChunkPolicy{MaxMutationCells: 30}})
+	s := []*Test{testRecord3, testRecord4}
+	results, err := m.ApplyInsertOrUpdateChunked(ctx, dataClient, &s)
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, 1, results[1].Offset)
+	assert.False(t, results[1].CommitTimestamp.IsZero())
+
+	results, err = m.ApplyInsertChunked(ctx, dataClient, &s)
+	assert.ErrorIs(t, err, ErrAlreadyExists)
+	assert.Len(t, results, 1)
+
+	results, err = m.ApplyDeleteChunked(ctx, dataClient, &s)
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+}
+
+func TestDML_ApplyInsertChunked(t *testing.T) {
+	ctx := context.Background()
+	d := NewDMLWithOptions("Test", &Options{ChunkPolicy: &ChunkPolicy{MaxParams: 30}})
+	s := []Test{*testRecord3, *testRecord4}
+	results, err := d.ApplyInsertChunked(ctx, dataClient, &s)
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, int64(1), results[0].RowCount)
+	assert.Equal(t, int64(1), results[1].RowCount)
+
+	results, err = d.ApplyUpdateChunked(ctx, dataClient, &s)
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, int64(1), results[1].RowCount)
+
+	_, err = dataClient.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		results, err := d.DeleteChunked(ctx, tx, &s)
+		assert.Len(t, results, 1)
+		assert.Equal(t, int64(2), results[0].RowCount)
+		return err
+	})
+	assert.Nil(t, err)
+}
+
+func TestMutation_splitChunksSoftDelete(t *testing.T) {
+	m := NewMutationWithOptions("Test", &Options{SoftDeleteColumn: "DeletedAt", ChunkPolicy: &ChunkPolicy{MaxMutationCells: 6}})
+	s := []Test{*testRecord1, *testRecord2, *testRecord3}
+	// soft delete writes 2 pk columns and the soft delete column
+	assert.Equal(t, []chunk{{offset: 0, len: 2}, {offset: 2, len: 1}}, m.splitChunks(toStructSlice(&s), true, m.getChunkPolicy()))
+}
+
+func TestDML_splitChunksUpdate(t *testing.T) {
+	s := []*Test{testRecord1, testRecord2, testRecord3}
+	// the params are summed up for the multi-row insert statement
+	d := NewDMLWithOptions("Test", &Options{ChunkPolicy: &ChunkPolicy{MaxParams: 50}})
+	assert.Equal(t, []chunk{{offset: 0, len: 2}, {offset: 2, len: 1}}, d.splitChunks(&s, false, chunkInsert, d.getChunkPolicy()))
+	// but not for the update statements executed for each record
+	assert.Equal(t, []chunk{{offset: 0, len: 3}}, d.splitChunks(&s, false, chunkUpdate, d.getChunkPolicy()))
+
+	d = NewDMLWithOptions("Test", &Options{ChunkPolicy: &ChunkPolicy{MaxParams: 50, MaxStatements: 2}})
+	assert.Equal(t, []chunk{{offset: 0, len: 2}, {offset: 2, len: 1}}, d.splitChunks(&s, false, chunkUpdate, d.getChunkPolicy()))
+}
@@ -2,7 +2,7 @@ package main
 
 import (
 	"fmt"
-	"github.com/kanjih/go-spnr/handlers/build"
+	"github.com/kanjih/go-spnr/v2/handlers/build"
 	"github.com/urfave/cli/v2"
 	"os"
 )
@@ -41,6 +41,18 @@ func main() {
 					Usage:    "package name",
 					Required: false,
 				},
+				&cli.BoolFlag{
+					Name:  build.FlagNamePointer,
+					Usage: "use pointer types (e.g. *string) for nullable columns instead of spanner.Null* types",
+				},
+				&cli.StringSliceFlag{
+					Name:  build.FlagNameJSON,
+					Usage: "Go type of JSON column (not ARRAY<JSON>) instead of spanner.NullJSON (e.g. Singers.Profile=github.com/foo/bar/model.Profile)",
+				},
+				&cli.StringSliceFlag{
+					Name:  build.FlagNameProto,
+					Usage: "Go import path of protobuf package for PROTO and ENUM columns (e.g. examples.music=github.com/foo/bar/musicpb)",
+				},
 			},
 			Action: build.Run,
 		},
@@ -0,0 +1,437 @@
+package spnr
+
+import (
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/pkg/errors"
+)
+
+// Built-in codecs which can be specified by codec option of spanner tag (e.g. `spanner:"Status,codec=enumstring"`).
+const (
+	// CodecText maps encoding.TextMarshaler and encoding.TextUnmarshaler to STRING column.
+	CodecText = "text"
+	// CodecUUID maps UUID (16 byte array like uuid.UUID of github.com/google/uuid) to STRING column in the canonical form.
+	CodecUUID = "uuid"
+	// CodecUUIDBytes maps UUID (16 byte array) to BYTES(16) column.
+	CodecUUIDBytes = "uuidbytes"
+	// CodecEnum maps integer types (e.g. enums, time.Duration) to INT64 column.
+	CodecEnum = "enum"
+	// CodecEnumString maps integer enums to STRING column using the String method.
+	// The values need to be registered by RegisterEnum to be decoded.
+	CodecEnumString = "enumstring"
+)
+
+// codec converts a Go value from/to the value which the spanner client can encode/decode.
+type codec struct {
+	encode func(v reflect.Value) (any, error)
+	decode func(gcv spanner.GenericColumnValue, v reflect.Value) error
+	// null is written for the nil pointer field.
+	null any
+}
+
+// jsonCodec is used for the fields with json option (e.g. `spanner:"Payload,json"`).
+var jsonCodec = &codec{encode: encodeJSON, decode: decodeJSON, null: spanner.NullJSON{}}
+
+var (
+	typeCodecs  sync.Map // map[reflect.Type]*codec
+	namedCodecs sync.Map // map[string]*codec
+	enumNames   sync.Map // map[reflect.Type]map[string]int64
+)
+
+func init() {
+	namedCodecs.Store(CodecText, &codec{encode: encodeText, decode: decodeText, null: spanner.NullString{}})
+	namedCodecs.Store(CodecUUID, &codec{encode: encodeUUID, decode: decodeUUID, null: spanner.NullString{}})
+	namedCodecs.Store(CodecUUIDBytes, &codec{encode: encodeUUIDBytes, decode: decodeUUIDBytes, null: []byte(nil)})
+	namedCodecs.Store(CodecEnum, &codec{encode: encodeEnum, decode: decodeEnum, null: spanner.NullInt64{}})
+	namedCodecs.Store(CodecEnumString, &codec{encode: encodeEnumString, decode: decodeEnumString, null: spanner.NullString{}})
+}
+
+/*
+RegisterType registers the functions to convert T from/to S, which the spanner client can encode/decode (e.g. string, int64, []byte, big.Rat).
+The fields of T (or *T) are converted by them on both writes and reads without any tag options.
+Register the types before using them (e.g. in init function), since the tags are validated on the first use.
+
+	spnr.RegisterType(
+		func(d time.Duration) (int64, error) { return d.Milliseconds(), nil },
+		func(ms int64) (time.Duration, error) { return time.Duration(ms) * time.Millisecond, nil },
+	)
+*/
+func RegisterType[T, S any](encode func(T) (S, error), decode func(S) (T, error)) {
+	typeCodecs.Store(reflect.TypeOf((*T)(nil)).Elem(), newTypedCodec("", encode, decode))
+}
+
+// RegisterCodec is basically same as RegisterType, but the functions are used only for the fields with codec option (e.g. `spanner:"Price,codec=money"`).
+// It's useful when T is mapped to different column types, or T is a common type like string.
+func RegisterCodec[T, S any](name string, encode func(T) (S, error), decode func(S) (T, error)) {
+	namedCodecs.Store(name, newTypedCodec(name, encode, decode))
+}
+
+// RegisterEnum registers the values of the integer enum to decode them by CodecEnumString.
+// The values are mapped from the strings returned by String method.
+func RegisterEnum[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64](values ...T) {
+	names := make(map[string]int64, len(values))
+	for _, v := range values {
+		names[fmt.Sprint(v)] = int64(v)
+	}
+	enumNames.Store(reflect.TypeOf((*T)(nil)).Elem(), names)
+}
+
+func newTypedCodec[T, S any](name string, encode func(T) (S, error), decode func(S) (T, error)) *codec {
+	tp := reflect.TypeOf((*T)(nil)).Elem()
+	check := func(v reflect.Value) error {
+		if v.Type() != tp {
+			return errors.Errorf("codec %s is for %s but the field is %s", name, tp, v.Type())
+		}
+		return nil
+	}
+	return &codec{
+		encode: func(v reflect.Value) (any, error) {
+			if err := check(v); err != nil {
+				return nil, err
+			}
+			return encode(v.Interface().(T))
+		},
+		decode: func(gcv spanner.GenericColumnValue, v reflect.Value) error {
+			if err := check(v); err != nil {
+				return err
+			}
+			var s S
+			if err := gcv.Decode(&s); err != nil {
+				return errors.WithStack(err)
+			}
+			t, err := decode(s)
+			if err != nil {
+				return err
+			}
+			v.Set(reflect.ValueOf(&t).Elem())
+			return nil
+		},
+		null: nullOf(reflect.TypeOf((*S)(nil)).Elem()),
+	}
+}
+
+// nullOf returns the NULL value of the type which the spanner client can encode.
+func nullOf(tp reflect.Type) any {
+	if tp.Kind() == reflect.Slice || tp.Implements(reflect.TypeOf((*spanner.NullableValue)(nil)).Elem()) {
+		return reflect.Zero(tp).Interface()
+	}
+	return reflect.Zero(reflect.PointerTo(tp)).Interface()
+}
+
+func hasTypeCodec(tp reflect.Type) bool {
+	_, ok := typeCodecs.Load(tp)
+	if !ok && tp.Kind() == reflect.Ptr {
+		_, ok = typeCodecs.Load(tp.Elem())
+	}
+	return ok
+}
+
+// codecOf returns the codec of the field, or nil if the field is encoded/decoded by the spanner client.
+// deref is true if the codec is for the element of the pointer field.
+func codecOf(f fieldInfo) (c *codec, deref bool, err error) {
+	if f.opts.json {
+		return jsonCodec, f.typ.Kind() == reflect.Ptr, nil
+	}
+	if f.opts.codec != "" {
+		c, ok := namedCodecs.Load(f.opts.codec)
+		if !ok {
+			return nil, false, errors.Errorf("codec %s is not registered", f.opts.codec)
+		}
+		return c.(*codec), f.typ.Kind() == reflect.Ptr, nil
+	}
+	if c, ok := typeCodecs.Load(f.typ); ok {
+		return c.(*codec), false, nil
+	}
+	if f.typ.Kind() == reflect.Ptr {
+		if c, ok := typeCodecs.Load(f.typ.Elem()); ok {
+			return c.(*codec), true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// encodeError is written instead of the value which failed to be encoded.
+// The spanner client returns the error when it encodes the mutation or the statement.
+type encodeError struct {
+	err error
+}
+
+func (e encodeError) EncodeSpanner() (any, error) {
+	return nil, e.err
+}
+
+// encodeField returns the value of the field to be written.
+func encodeField(f fieldInfo, v reflect.Value) any {
+	c, deref, err := codecOf(f)
+	if err != nil {
+		return encodeError{err: err}
+	}
+	if c == nil {
+		return v.Interface()
+	}
+	if deref {
+		if v.IsNil() {
+			return c.null
+		}
+		v = v.Elem()
+	}
+	encoded, err := c.encode(v)
+	if err != nil {
+		return encodeError{err: errors.Wrapf(err, "failed to encode field %s", f.name)}
+	}
+	return encoded
+}
+
+// decodeField decodes the value into the field.
+func decodeField(f fieldInfo, gcv spanner.GenericColumnValue, v reflect.Value) error {
+	c, deref, err := codecOf(f)
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		return decodeValue(gcv, v)
+	}
+	if deref {
+		if isNull(gcv) {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		e := reflect.New(v.Type().Elem())
+		if err := c.decode(gcv, e.Elem()); err != nil {
+			return err
+		}
+		v.Set(e)
+		return nil
+	}
+	return c.decode(gcv, v)
+}
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	stringerType        = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// addressable returns the addressable copy of the value to call the methods of pointer receivers.
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	p := reflect.New(v.Type()).Elem()
+	p.Set(v)
+	return p
+}
+
+func encodeText(v reflect.Value) (any, error) {
+	v = addressable(v)
+	m, ok := v.Addr().Interface().(encoding.TextMarshaler)
+	if !ok {
+		return nil, errors.Errorf("%s doesn't implement %s", v.Type(), textMarshalerType)
+	}
+	b, err := m.MarshalText()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return string(b), nil
+}
+
+func decodeText(gcv spanner.GenericColumnValue, v reflect.Value) error {
+	u, ok := v.Addr().Interface().(encoding.TextUnmarshaler)
+	if !ok {
+		return errors.Errorf("%s doesn't implement %s", v.Type(), textUnmarshalerType)
+	}
+	var s spanner.NullString
+	if err := gcv.Decode(&s); err != nil {
+		return errors.WithStack(err)
+	}
+	if !s.Valid {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	return errors.WithStack(u.UnmarshalText([]byte(s.StringVal)))
+}
+
+func isUUIDType(tp reflect.Type) bool {
+	return tp.Kind() == reflect.Array && tp.Len() == 16 && tp.Elem().Kind() == reflect.Uint8
+}
+
+func uuidBytes(v reflect.Value) ([]byte, error) {
+	if !isUUIDType(v.Type()) {
+		return nil, errors.Errorf("%s is not a 16 byte array", v.Type())
+	}
+	b := make([]byte, 16)
+	reflect.Copy(reflect.ValueOf(b), v)
+	return b, nil
+}
+
+func setUUIDBytes(v reflect.Value, b []byte) error {
+	if !isUUIDType(v.Type()) {
+		return errors.Errorf("%s is not a 16 byte array", v.Type())
+	}
+	if len(b) != 16 {
+		return errors.Errorf("uuid must be 16 bytes but got %d bytes", len(b))
+	}
+	reflect.Copy(v, reflect.ValueOf(b))
+	return nil
+}
+
+func encodeUUID(v reflect.Value) (any, error) {
+	b, err := uuidBytes(v)
+	if err != nil {
+		return nil, err
+	}
+	s := hex.EncodeToString(b)
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:], nil
+}
+
+func decodeUUID(gcv spanner.GenericColumnValue, v reflect.Value) error {
+	var s spanner.NullString
+	if err := gcv.Decode(&s); err != nil {
+		return errors.WithStack(err)
+	}
+	if !s.Valid {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	b, err := hex.DecodeString(strings.ReplaceAll(s.StringVal, "-", ""))
+	if err != nil {
+		return errors.Wrapf(err, "invalid uuid %s", s.StringVal)
+	}
+	return setUUIDBytes(v, b)
+}
+
+func encodeUUIDBytes(v reflect.Value) (any, error) {
+	return uuidBytes(v)
+}
+
+func decodeUUIDBytes(gcv spanner.GenericColumnValue, v reflect.Value) error {
+	var b []byte
+	if err := gcv.Decode(&b); err != nil {
+		return errors.WithStack(err)
+	}
+	if b == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	return setUUIDBytes(v, b)
+}
+
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUintKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func encodeEnum(v reflect.Value) (any, error) {
+	switch {
+	case isIntKind(v.Kind()):
+		return v.Int(), nil
+	case isUintKind(v.Kind()):
+		return int64(v.Uint()), nil
+	}
+	return nil, errors.Errorf("%s is not an integer type", v.Type())
+}
+
+func setEnum(v reflect.Value, n int64) error {
+	switch {
+	case isIntKind(v.Kind()):
+		v.SetInt(n)
+	case isUintKind(v.Kind()):
+		v.SetUint(uint64(n))
+	default:
+		return errors.Errorf("%s is not an integer type", v.Type())
+	}
+	return nil
+}
+
+// decodeEnum decodes INT64 column into the integer enum.
+// NULL is not decoded into the zero value, since it's usually a valid value of the enum. Use a pointer field for nullable columns.
+func decodeEnum(gcv spanner.GenericColumnValue, v reflect.Value) error {
+	var n spanner.NullInt64
+	if err := gcv.Decode(&n); err != nil {
+		return errors.WithStack(err)
+	}
+	if !n.Valid {
+		return errNullEnum(v.Type())
+	}
+	return setEnum(v, n.Int64)
+}
+
+func errNullEnum(tp reflect.Type) error {
+	return errors.Errorf("cannot decode NULL into %s, use a pointer field for nullable enum column", tp)
+}
+
+func encodeEnumString(v reflect.Value) (any, error) {
+	if !isIntKind(v.Kind()) && !isUintKind(v.Kind()) {
+		return nil, errors.Errorf("%s is not an integer type", v.Type())
+	}
+	s, ok := v.Interface().(fmt.Stringer)
+	if !ok {
+		return nil, errors.Errorf("%s doesn't implement %s", v.Type(), stringerType)
+	}
+	return s.String(), nil
+}
+
+// decodeEnumString decodes STRING column into the integer enum registered by RegisterEnum.
+// NULL is rejected like decodeEnum.
+func decodeEnumString(gcv spanner.GenericColumnValue, v reflect.Value) error {
+	var s spanner.NullString
+	if err := gcv.Decode(&s); err != nil {
+		return errors.WithStack(err)
+	}
+	if !s.Valid {
+		return errNullEnum(v.Type())
+	}
+	names, ok := enumNames.Load(v.Type())
+	if !ok {
+		return errors.Errorf("%s is not registered by RegisterEnum", v.Type())
+	}
+	n, ok := names.(map[string]int64)[s.StringVal]
+	if !ok {
+		return errors.Errorf("unknown value %s of %s", s.StringVal, v.Type())
+	}
+	return setEnum(v, n)
+}
+
+// encodeJSON marshals the value into JSON column.
+// nil maps and slices are written as NULL, not as JSON null.
+func encodeJSON(v reflect.Value) (any, error) {
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Interface:
+		if v.IsNil() {
+			return spanner.NullJSON{}, nil
+		}
+	}
+	return spanner.NullJSON{Value: v.Interface(), Valid: true}, nil
+}
+
+// decodeJSON unmarshals JSON (or STRING) column into the value.
+// ARRAY<JSON> columns are not supported by json option, use []spanner.NullJSON for them.
+func decodeJSON(gcv spanner.GenericColumnValue, v reflect.Value) error {
+	if isNull(gcv) {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if code := gcv.Type.GetCode(); code != sppb.TypeCode_JSON && code != sppb.TypeCode_STRING {
+		return errors.Errorf("cannot decode %s into %s as JSON", code, v.Type())
+	}
+	return errors.WithStack(json.Unmarshal([]byte(gcv.Value.GetStringValue()), v.Addr().Interface()))
+}
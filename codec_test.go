@@ -0,0 +1,210 @@
+package spnr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+)
+
+type testUUID [16]byte
+
+type testStatus int
+
+const (
+	testStatusActive testStatus = iota + 1
+	testStatusDeleted
+)
+
+func (s testStatus) String() string {
+	switch s {
+	case testStatusActive:
+		return "ACTIVE"
+	case testStatusDeleted:
+		return "DELETED"
+	}
+	return fmt.Sprintf("testStatus(%d)", int(s))
+}
+
+type testUpper string
+
+func (u testUpper) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(string(u))), nil
+}
+
+func (u *testUpper) UnmarshalText(b []byte) error {
+	*u = testUpper(strings.ToLower(string(b)))
+	return nil
+}
+
+type testCodecs struct {
+	ID        testUUID       `spanner:"ID,codec=uuid"`
+	IDBytes   testUUID       `spanner:"IDBytes,codec=uuidbytes"`
+	Status    testStatus     `spanner:"Status,codec=enumstring"`
+	StatusInt *testStatus    `spanner:"StatusInt,codec=enum"`
+	Upper     testUpper      `spanner:"Upper,codec=text"`
+	Timeout   time.Duration  `spanner:"Timeout"`
+	Interval  *time.Duration `spanner:"Interval"`
+}
+
+func init() {
+	RegisterEnum(testStatusActive, testStatusDeleted)
+	RegisterType(
+		func(d time.Duration) (int64, error) { return d.Milliseconds(), nil },
+		func(ms int64) (time.Duration, error) { return time.Duration(ms) * time.Millisecond, nil },
+	)
+}
+
+func TestCodecs(t *testing.T) {
+	assert.Nil(t, Validate(testCodecs{}))
+
+	id := testUUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	status := testStatusDeleted
+	record := testCodecs{ID: id, IDBytes: id, Status: testStatusActive, StatusInt: &status, Upper: "abc", Timeout: 3 * time.Second}
+
+	values := toValues(&record)
+	assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", values[0])
+	assert.Equal(t, id[:], values[1])
+	assert.Equal(t, "ACTIVE", values[2])
+	assert.Equal(t, int64(2), values[3])
+	assert.Equal(t, "ABC", values[4])
+	assert.Equal(t, int64(3000), values[5])
+	assert.Equal(t, (*int64)(nil), values[6])
+
+	row, err := spanner.NewRow(getStructInfo(reflect.TypeOf(record)).columns, values)
+	assert.Nil(t, err)
+	var decoded testCodecs
+	assert.Nil(t, decodeRow(row, &decoded))
+	assert.Equal(t, record, decoded)
+
+	// NULL is decoded into nil
+	row, err = spanner.NewRow([]string{"StatusInt", "Interval"}, []any{spanner.NullInt64{}, int64(10)})
+	assert.Nil(t, err)
+	assert.Nil(t, decodeRow(row, &decoded))
+	assert.Nil(t, decoded.StatusInt)
+	assert.Equal(t, 10*time.Millisecond, *decoded.Interval)
+
+	row, err = spanner.NewRow([]string{"Status"}, []any{"UNKNOWN"})
+	assert.Nil(t, err)
+	assert.NotNil(t, decodeRow(row, &decoded))
+
+	// NULL is not decoded into the zero value of non-pointer enum
+	row, err = spanner.NewRow([]string{"Status"}, []any{spanner.NullString{}})
+	assert.Nil(t, err)
+	assert.NotNil(t, decodeRow(row, &decoded))
+	var enum struct {
+		Status testStatus `spanner:"Status,codec=enum"`
+	}
+	row, err = spanner.NewRow([]string{"Status"}, []any{spanner.NullInt64{}})
+	assert.Nil(t, err)
+	assert.ErrorContains(t, decodeRow(row, &enum), "cannot decode NULL")
+}
+
+func TestCodecs_invalid(t *testing.T) {
+	type invalid struct {
+		A string `spanner:"A,codec=notExist"`
+		B string `spanner:"B,unknown"`
+		C string `spanner:"C,codec=uuid"`
+	}
+	err := Validate(invalid{})
+	var vErr *ValidationError
+	assert.ErrorAs(t, err, &vErr)
+	assert.Len(t, vErr.Errors, 1)
+	assert.Equal(t, TagErrInvalidOption, vErr.Errors[0].Kind)
+
+	// the errors are returned by the spanner client when it encodes the values
+	for _, v := range toValues(&invalid{}) {
+		_, err := spanner.NewRow([]string{"v"}, []any{v})
+		if v == "" {
+			assert.Nil(t, err)
+		} else {
+			assert.NotNil(t, err)
+		}
+	}
+}
+
+func TestRegisterCodec(t *testing.T) {
+	RegisterCodec("cents", func(v float64) (int64, error) { return int64(v * 100), nil }, func(v int64) (float64, error) { return float64(v) / 100, nil })
+	type price struct {
+		Price float64 `spanner:"Price,codec=cents"`
+	}
+	assert.Equal(t, []any{int64(150)}, toValues(&price{Price: 1.5}))
+}
+
+func TestCodecs_readWrite(t *testing.T) {
+	type testCodecRecord struct {
+		String     testUUID    `spanner:"String,codec=uuid" pk:"1"`
+		Int64      int64       `spanner:"Int64" pk:"2"`
+		NullString testStatus  `spanner:"NullString,codec=enumstring"`
+		NullInt64  *testStatus `spanner:"NullInt64,codec=enum"`
+	}
+	ctx := context.Background()
+	id := testUUID{1, 2, 3}
+	record := *testRecord3
+	record.String = "01020300-0000-0000-0000-000000000000"
+	_, err := testRepository.ApplyInsertOrUpdate(ctx, dataClient, &record)
+	assert.Nil(t, err)
+
+	store := NewDMLStore[testCodecRecord]("Test")
+	status := testStatusDeleted
+	_, err = dataClient.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		_, err := store.Update(ctx, tx, &testCodecRecord{String: id, Int64: record.Int64, NullString: testStatusActive, NullInt64: &status})
+		return err
+	})
+	assert.Nil(t, err)
+
+	fetched, err := store.FindOne(ctx, dataClient.Single(), spanner.Key{record.String, record.Int64})
+	assert.Nil(t, err)
+	assert.Equal(t, id, fetched.String)
+	assert.Equal(t, testStatusActive, fetched.NullString)
+	assert.Equal(t, testStatusDeleted, *fetched.NullInt64)
+
+	_, err = testRepository.ApplyDelete(ctx, dataClient, &record)
+	assert.Nil(t, err)
+}
+
+type testPayload struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+func TestJSON(t *testing.T) {
+	type testJSON struct {
+		Payload  testPayload       `spanner:"Payload,json"`
+		PPayload *testPayload      `spanner:"PPayload,json"`
+		Attrs    map[string]string `spanner:"Attrs,json"`
+		Raw      spanner.NullJSON  `spanner:"Raw"`
+	}
+	assert.Nil(t, Validate(testJSON{}))
+
+	record := testJSON{Payload: testPayload{Name: "a", Tags: []string{"x"}}, Raw: spanner.NullJSON{Value: []int{1}, Valid: true}}
+	values := toValues(&record)
+	assert.Equal(t, spanner.NullJSON{Value: record.Payload, Valid: true}, values[0])
+	assert.Equal(t, spanner.NullJSON{}, values[1])
+	assert.Equal(t, spanner.NullJSON{}, values[2])
+
+	row, err := spanner.NewRow([]string{"Payload", "PPayload", "Attrs"}, values[:3])
+	assert.Nil(t, err)
+	var decoded testJSON
+	assert.Nil(t, decodeRow(row, &decoded))
+	assert.Equal(t, record.Payload, decoded.Payload)
+	assert.Nil(t, decoded.PPayload)
+	assert.Nil(t, decoded.Attrs)
+
+	record = testJSON{PPayload: &testPayload{Name: "b"}, Attrs: map[string]string{"k": "v"}}
+	row, err = spanner.NewRow([]string{"PPayload", "Attrs"}, toValues(&record)[1:3])
+	assert.Nil(t, err)
+	assert.Nil(t, decodeRow(row, &decoded))
+	assert.Equal(t, record.PPayload, decoded.PPayload)
+	assert.Equal(t, record.Attrs, decoded.Attrs)
+
+	type invalid struct {
+		A testPayload `spanner:"A,json,codec=text"`
+	}
+	assert.NotNil(t, Validate(invalid{}))
+}
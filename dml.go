@@ -3,6 +3,9 @@ package spnr
 import (
 	"context"
 	"fmt"
+	"strings"
+
+	"cloud.google.com/go/spanner"
 )
 
 const dmlLogTemplate = "executing dml... sql:%s, params:%s"
@@ -10,15 +13,47 @@ const dmlLogTemplate = "executing dml... sql:%s, params:%s"
 // DML offers ORM with DML.
 // It also contains read operations (call Reader method.)
 type DML struct {
-	table      string
-	logger     logger
-	logEnabled bool
+	table       string
+	logger      logger
+	logEnabled  bool
+	strict      bool
+	chunkPolicy *ChunkPolicy
+	pageKey     []byte
+	indexes     map[string][]string
+	opts        requestOptions
+	bound       *spanner.TimestampBound
+	softDelete  string
 }
 
 // Options is for specifying the options for spnr.Mutation and spnr.DML.
 type Options struct {
 	Logger     logger
 	LogEnabled bool
+	// StrictValidation makes operations return an error for every problem in spnr tags (see Validate).
+	// Without it, only invalid or duplicate pk tags are reported.
+	StrictValidation bool
+	// ChunkPolicy is the limits used by the XXXChunked methods to split large writes.
+	// DefaultChunkPolicy is used if it's nil.
+	ChunkPolicy *ChunkPolicy
+	// PageTokenKey is the secret key to sign the page tokens of Reader.Page.
+	// It's required to read more than one page, and must be the same in all the processes serving the tokens.
+	PageTokenKey []byte
+	// Indexes declares the columns stored in the secondary indexes (the key columns and STORING columns) by index name.
+	// Reader.FindAllByIndex reads the records only from the index if it stores all the columns of the struct.
+	// Otherwise, the index is treated as non-covering and the base table is read by the primary keys found in the index.
+	Indexes map[string][]string
+	// RequestOptions is the default options of the requests (e.g. request tag, priority).
+	// You can add the options for each call with With method.
+	RequestOptions []Option
+	// TimestampBound is the default timestamp bound of the reads by SingleReader (e.g. spanner.MaxStaleness(10*time.Second)).
+	// Strong read is used if it's nil.
+	TimestampBound *spanner.TimestampBound
+	// SoftDeleteColumn is the TIMESTAMP column which marks the records as deleted (e.g. "DeletedAt").
+	// If it's specified, Delete sets the commit timestamp to the column instead of deleting the records,
+	// and the reads by keys (e.g. Reader.FindOne, Reader.FindAllByIndex) and Reader.Page don't return the records whose column is not NULL.
+	// The queries passed to Reader (e.g. Reader.Query) are executed as they are.
+	// The column must have allow_commit_timestamp=true option.
+	SoftDeleteColumn string
 }
 
 // NewDML initializes ORM with DML.
@@ -31,7 +66,7 @@ func NewDML(tableName string) *DML {
 // NewDMLWithOptions initializes DML with options.
 // Check Options for the available options.
 func NewDMLWithOptions(tableName string, op *Options) *DML {
-	dml := &DML{table: tableName, logger: op.Logger, logEnabled: op.LogEnabled}
+	dml := &DML{table: tableName, logger: op.Logger, logEnabled: op.LogEnabled, strict: op.StrictValidation, chunkPolicy: op.ChunkPolicy, pageKey: op.PageTokenKey, indexes: op.Indexes, opts: newRequestOptions(op.RequestOptions), bound: op.TimestampBound, softDelete: op.SoftDeleteColumn}
 	if dml.logger == nil {
 		dml.logger = newDefaultLogger()
 	}
@@ -40,7 +75,26 @@ func NewDMLWithOptions(tableName string, op *Options) *DML {
 
 // Reader returns Reader struct to call read operations.
 func (d *DML) Reader(ctx context.Context, tx Transaction) *Reader {
-	return &Reader{table: d.table, ctx: ctx, tx: tx, logger: d.logger, logEnabled: d.logEnabled}
+	return &Reader{table: d.table, ctx: ctx, tx: tx, logger: d.logger, logEnabled: d.logEnabled, strict: d.strict, pageKey: d.pageKey, indexes: d.indexes, opts: d.opts, softDelete: d.softDelete}
+}
+
+// StaleReader returns Reader which executes each read in a new single-use read-only transaction with the timestamp bound.
+// See StaleTransaction for the details.
+func (d *DML) StaleReader(ctx context.Context, client *spanner.Client, bound spanner.TimestampBound) *Reader {
+	return d.Reader(ctx, NewStaleTransaction(client, bound))
+}
+
+// SingleReader is basically same as StaleReader, but it reads with Options.TimestampBound (strong read if it's not specified).
+func (d *DML) SingleReader(ctx context.Context, client *spanner.Client) *Reader {
+	return d.StaleReader(ctx, client, defaultBound(d.bound))
+}
+
+// With returns the copy of DML which sends the requests with the passed options.
+// The options are added to the ones specified in Options.RequestOptions.
+func (d *DML) With(opts ...Option) *DML {
+	c := *d
+	c.opts = d.opts.with(opts...)
+	return &c
 }
 
 // GetTableName returns table name
@@ -52,14 +106,32 @@ func (d *DML) getTableName() string {
 	return quote(d.table)
 }
 
+func (d *DML) validate(target any) (isStruct bool, err error) {
+	isStruct, err = validateStructOrStructSliceType(target)
+	if err != nil {
+		return false, err
+	}
+	return isStruct, validateTags(target, d.strict)
+}
+
+func (d *DML) update(ctx context.Context, tx *spanner.ReadWriteTransaction, stmt *spanner.Statement) (int64, error) {
+	return tx.UpdateWithOptions(ctx, *stmt, d.opts.queryOptions())
+}
+
+func (d *DML) getChunkPolicy() ChunkPolicy {
+	if d.chunkPolicy == nil {
+		return DefaultChunkPolicy
+	}
+	return *d.chunkPolicy
+}
+
 func (d *DML) log(sql string, params map[string]any) {
 	if !d.logEnabled {
 		return
 	}
-	var paramsStr string
+	var paramsStr []string
 	for k, v := range params {
-		paramsStr += fmt.Sprintf("%s=%+v,", k, v)
+		paramsStr = append(paramsStr, fmt.Sprintf("%s=%+v", k, v))
 	}
-	paramsStr = paramsStr[:len(paramsStr)-1]
-	d.logger.Printf(dmlLogTemplate, sql, paramsStr)
+	d.logger.Printf(dmlLogTemplate, sql, strings.Join(paramsStr, ","))
 }
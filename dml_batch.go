@@ -0,0 +1,47 @@
+package spnr
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+)
+
+// BatchError is returned when a statement executed by BatchUpdate fails.
+// The statements after the failed one are not executed.
+type BatchError struct {
+	// Index is the index of the failed statement, which is the same as the index of the struct in the passed slice.
+	Index int
+	// RowCounts is the row counts of the statements succeeded before the failure.
+	RowCounts []int64
+	Err       error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("statement %d in batch failed: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error of the failed statement.
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+func (d *DML) batchUpdate(ctx context.Context, tx *spanner.ReadWriteTransaction, stmts []spanner.Statement) ([]int64, error) {
+	if len(stmts) == 0 {
+		return nil, nil
+	}
+	rowCounts, err := tx.BatchUpdateWithOptions(ctx, stmts, d.opts.queryOptions())
+	if err != nil {
+		return rowCounts, errors.WithStack(&BatchError{Index: len(rowCounts), RowCounts: rowCounts, Err: err})
+	}
+	return rowCounts, nil
+}
+
+func sum(rowCounts []int64) int64 {
+	var total int64
+	for _, c := range rowCounts {
+		total += c
+	}
+	return total
+}
@@ -0,0 +1,60 @@
+package spnr
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDML_buildUpdateStmts(t *testing.T) {
+	stmts := testDMLRepository.buildUpdateStmts(toStructSlice(&([]Test{*testRecord1, *testRecord2})), []string{"NullString"})
+	assert.Len(t, stmts, 2)
+	for i, r := range []*Test{testRecord1, testRecord2} {
+		assert.Equal(t, "UPDATE `Test` SET `NullString`=@NullString WHERE `String`=@w_String AND `Int64`=@w_Int64", stmts[i].SQL)
+		assert.Equal(t, r.String, stmts[i].Params["w_String"])
+		assert.Equal(t, r.NullString, stmts[i].Params["NullString"])
+	}
+}
+
+func TestBatchError(t *testing.T) {
+	var err error = errors.WithStack(&BatchError{Index: 1, RowCounts: []int64{1}, Err: ErrAlreadyExists})
+	assert.True(t, errors.Is(err, ErrAlreadyExists))
+	var be *BatchError
+	assert.True(t, errors.As(err, &be))
+	assert.Equal(t, 1, be.Index)
+	assert.Equal(t, "statement 1 in batch failed: record already exists", be.Error())
+}
+
+func TestDML_UpdateColumnsBatch(t *testing.T) {
+	_, err := dataClient.ReadWriteTransaction(context.Background(), func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		_, err := testDMLRepository.Insert(ctx, tx, &([]*Test{testRecord3, testRecord4}))
+		assert.Nil(t, err)
+
+		testRecord5 := *testRecord3
+		testRecord6 := *testRecord4
+		testRecord5.NullString = NewNullString("updated5")
+		testRecord5.Float64 = 0
+		testRecord6.NullString = NewNullString("updated6")
+		notExist := *testRecord3
+		notExist.String = "notExist"
+
+		rowCounts, err := testDMLRepository.UpdateColumnsBatch(ctx, tx, []string{"NullString"}, &([]Test{testRecord5, notExist, testRecord6}))
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{1, 0, 1}, rowCounts)
+
+		var fetched Test
+		err = testRepository.Reader(ctx, tx).FindOne(spanner.Key{testRecord5.String, testRecord5.Int64}, &fetched)
+		assert.Nil(t, err)
+		assert.Equal(t, testRecord5.NullString, fetched.NullString)
+		assert.Equal(t, testRecord3.Float64, fetched.Float64)
+
+		rowCounts, err = testDMLRepository.DeleteBatch(ctx, tx, &([]*Test{testRecord3, testRecord4}))
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{1, 1}, rowCounts)
+		return nil
+	})
+	assert.Nil(t, err)
+}
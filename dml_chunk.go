@@ -0,0 +1,163 @@
+package spnr
+
+import (
+	"context"
+	"reflect"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+)
+
+// InsertChunked is basically same as Insert, but it splits the records into chunks following the ChunkPolicy in Options,
+// and executes an insert statement for each chunk in the passed transaction.
+// Use it when the records don't fit in one statement.
+// The chunked methods of DML cover insert, update and delete. To update the specific columns, use UpdateColumnsBatch.
+// It returns the result of each executed chunk. The returned error is the first error of the chunks.
+func (d *DML) InsertChunked(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) ([]ChunkResult, error) {
+	return d.execChunked(ctx, tx, target, d.execStmt(d.buildInsertChunkStmt), chunkInsert)
+}
+
+// ApplyInsertChunked is basically same as InsertChunked, but it executes each chunk in a separate read-write transaction.
+// Use it when the records don't fit in one commit.
+// Note that the chunks committed before the failure are not rolled back.
+func (d *DML) ApplyInsertChunked(ctx context.Context, client *spanner.Client, target any) ([]ChunkResult, error) {
+	return d.applyChunked(ctx, client, target, d.execStmt(d.buildInsertChunkStmt), chunkInsert)
+}
+
+// DeleteChunked is basically same as Delete, but it splits the records into chunks.
+// See InsertChunked for the details.
+func (d *DML) DeleteChunked(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) ([]ChunkResult, error) {
+	return d.execChunked(ctx, tx, target, d.execStmt(d.buildDeleteChunkStmt), chunkDelete)
+}
+
+// ApplyDeleteChunked is basically same as DeleteChunked, but it executes each chunk in a separate read-write transaction.
+// See ApplyInsertChunked for the details.
+func (d *DML) ApplyDeleteChunked(ctx context.Context, client *spanner.Client, target any) ([]ChunkResult, error) {
+	return d.applyChunked(ctx, client, target, d.execStmt(d.buildDeleteChunkStmt), chunkDelete)
+}
+
+// UpdateChunked is basically same as Update, but it splits the records into chunks,
+// and executes the update statements of each chunk in one batch (see UpdateBatch.)
+// ErrStaleVersion is returned as the error of the chunk containing the stale record.
+// See InsertChunked for the details.
+func (d *DML) UpdateChunked(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) ([]ChunkResult, error) {
+	return d.execChunked(ctx, tx, target, d.execUpdateChunk, chunkUpdate)
+}
+
+// ApplyUpdateChunked is basically same as UpdateChunked, but it executes each chunk in a separate read-write transaction.
+// See ApplyInsertChunked for the details.
+func (d *DML) ApplyUpdateChunked(ctx context.Context, client *spanner.Client, target any) ([]ChunkResult, error) {
+	return d.applyChunked(ctx, client, target, d.execUpdateChunk, chunkUpdate)
+}
+
+// chunkExec executes the statements of the chunk c of target in the transaction, and returns the row count.
+type chunkExec func(ctx context.Context, tx *spanner.ReadWriteTransaction, target any, isStruct bool, c chunk) (int64, error)
+
+func (d *DML) execChunked(ctx context.Context, tx *spanner.ReadWriteTransaction, target any, exec chunkExec, kind chunkKind) ([]ChunkResult, error) {
+	isStruct, err := d.validate(target)
+	if err != nil {
+		return nil, err
+	}
+	policy := d.getChunkPolicy()
+	var results []ChunkResult
+	for _, c := range d.splitChunks(target, isStruct, kind, policy) {
+		rowCount, err := exec(ctx, tx, target, isStruct, c)
+		results = append(results, ChunkResult{Offset: c.offset, Len: c.len, RowCount: rowCount, Err: errors.WithStack(err)})
+		if err != nil && !policy.ContinueOnError {
+			break
+		}
+	}
+	return results, firstChunkErr(results)
+}
+
+func (d *DML) applyChunked(ctx context.Context, client *spanner.Client, target any, exec chunkExec, kind chunkKind) ([]ChunkResult, error) {
+	isStruct, err := d.validate(target)
+	if err != nil {
+		return nil, err
+	}
+	policy := d.getChunkPolicy()
+	var results []ChunkResult
+	for _, c := range d.splitChunks(target, isStruct, kind, policy) {
+		var rowCount int64
+		resp, err := client.ReadWriteTransactionWithOptions(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+			var err error
+			rowCount, err = exec(ctx, tx, target, isStruct, c)
+			return err
+		}, d.opts.transactionOptions())
+		results = append(results, ChunkResult{Offset: c.offset, Len: c.len, CommitTimestamp: resp.CommitTs, RowCount: rowCount, Err: errors.WithStack(err)})
+		if err != nil && !policy.ContinueOnError {
+			break
+		}
+	}
+	return results, firstChunkErr(results)
+}
+
+// chunkKind is the kind of the statements executed for the chunks, which decides how the records are counted.
+type chunkKind int
+
+const (
+	// chunkInsert executes one multi-row INSERT statement for a chunk.
+	chunkInsert chunkKind = iota
+	// chunkDelete executes one DELETE statement for the primary keys of a chunk.
+	chunkDelete
+	// chunkUpdate executes an UPDATE statement for each record of a chunk in one batch.
+	chunkUpdate
+)
+
+// splitChunks splits the records following the policy.
+// The params are summed up only for the multi-row statements, since ChunkPolicy.MaxParams is the limit per statement.
+// The update statements are limited by ChunkPolicy.MaxStatements instead.
+func (d *DML) splitChunks(target any, isStruct bool, kind chunkKind, policy ChunkPolicy) []chunk {
+	if isStruct {
+		return []chunk{{offset: 0, len: 1}}
+	}
+	slice := reflect.ValueOf(target).Elem()
+	return splitChunks(slice.Len(), func(i int) chunkCost {
+		fields := structValToFields(slice.Index(i))
+		if kind == chunkDelete {
+			fields = extractPks(fields)
+		}
+		var bytes int
+		for _, f := range fields {
+			bytes += estimateSize(f.value)
+		}
+		if kind == chunkUpdate {
+			return chunkCost{statements: 1, bytes: bytes}
+		}
+		return chunkCost{params: len(fields), bytes: bytes}
+	}, policy)
+}
+
+// execStmt returns the chunkExec which executes the statement built by build.
+func (d *DML) execStmt(build func(any, bool, chunk) *spanner.Statement) chunkExec {
+	return func(ctx context.Context, tx *spanner.ReadWriteTransaction, target any, isStruct bool, c chunk) (int64, error) {
+		return d.update(ctx, tx, build(target, isStruct, c))
+	}
+}
+
+// execUpdateChunk executes the update statements of the records in the chunk in one batch, and checks the versions of them.
+func (d *DML) execUpdateChunk(ctx context.Context, tx *spanner.ReadWriteTransaction, target any, isStruct bool, c chunk) (int64, error) {
+	targets := []any{target}
+	if !isStruct {
+		targets = toStructSlice(subSlice(target, c.offset, c.len))
+	}
+	rowCounts, err := d.batchUpdate(ctx, tx, d.buildUpdateStmts(targets, nil))
+	if err != nil {
+		return sum(rowCounts), err
+	}
+	return sum(rowCounts), checkRowCounts(target, rowCounts...)
+}
+
+func (d *DML) buildInsertChunkStmt(target any, isStruct bool, c chunk) *spanner.Statement {
+	if isStruct {
+		return d.buildInsertStmt(target)
+	}
+	return d.buildInsertAllStmt(subSlice(target, c.offset, c.len))
+}
+
+func (d *DML) buildDeleteChunkStmt(target any, isStruct bool, c chunk) *spanner.Statement {
+	if isStruct {
+		return d.buildDeleteStmt(target)
+	}
+	return d.buildDeleteAllStmt(subSlice(target, c.offset, c.len))
+}
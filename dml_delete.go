@@ -3,8 +3,6 @@ package spnr
 import (
 	"context"
 	"fmt"
-	"reflect"
-	"strings"
 
 	"cloud.google.com/go/spanner"
 	"github.com/pkg/errors"
@@ -12,23 +10,48 @@ import (
 
 // Delete build and execute delete statement from the passed struct.
 // You can pass either a struct or a slice of structs to target.
-// If you pass a slice of structs, this method will build statement which deletes multiple records in one statement like the following.
-//	DELETE FROM `T` WHERE (`COL1` = 'a' AND `COL2` = 'b') OR (`COL1` = 'c' AND `COL2` = 'd');
+// If you pass a slice of structs, this method will build delete statement for each struct and execute them in one batch (see DeleteBatch.)
+// If Options.SoftDeleteColumn is specified, the column is set to the commit timestamp instead of deleting the records,
+// and the records already soft deleted are not counted in rowCount. Use HardDelete to delete them physically.
 func (d *DML) Delete(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) (rowCount int64, err error) {
-	isStruct, err := validateStructOrStructSliceType(target)
+	isStruct, err := d.validate(target)
 	if err != nil {
 		return 0, err
 	}
 	if isStruct {
-		rowCount, err = tx.Update(ctx, *d.buildDeleteStmt(target))
+		rowCount, err = d.update(ctx, tx, d.buildDeleteStmt(target))
 		return rowCount, errors.WithStack(err)
 	} else {
-		rowCount, err := tx.Update(ctx, *d.buildDeleteAllStmt(target))
-		return rowCount, errors.WithStack(err)
+		rowCounts, err := d.batchUpdate(ctx, tx, d.buildDeleteStmts(toStructSlice(target)))
+		return sum(rowCounts), err
+	}
+}
+
+// DeleteBatch is basically same as Delete, but it returns the row count of each statement.
+// See UpdateBatch for the details.
+func (d *DML) DeleteBatch(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) (rowCounts []int64, err error) {
+	isStruct, err := d.validate(target)
+	if err != nil {
+		return nil, err
+	}
+	if isStruct {
+		return d.batchUpdate(ctx, tx, d.buildDeleteStmts([]any{target}))
 	}
+	return d.batchUpdate(ctx, tx, d.buildDeleteStmts(toStructSlice(target)))
+}
+
+func (d *DML) buildDeleteStmts(targets []any) []spanner.Statement {
+	stmts := make([]spanner.Statement, 0, len(targets))
+	for _, target := range targets {
+		stmts = append(stmts, *d.buildDeleteStmt(target))
+	}
+	return stmts
 }
 
 func (d *DML) buildDeleteStmt(target any) *spanner.Statement {
+	if d.softDelete != "" {
+		return d.buildSoftDeleteStmt(target, true)
+	}
 	fields := toFields(target)
 	whereClause, params := buildWherePK(fields)
 	sql := fmt.Sprintf("DELETE FROM %s WHERE %s",
@@ -43,23 +66,13 @@ func (d *DML) buildDeleteStmt(target any) *spanner.Statement {
 }
 
 func (d *DML) buildDeleteAllStmt(target any) *spanner.Statement {
-	var valuesList []string
-	params := map[string]any{}
-
-	slice := reflect.ValueOf(target).Elem()
-	for i := 0; i < slice.Len(); i++ {
-		var values []string
-		for _, field := range extractPks(structValToFields(slice.Index(i))) {
-			param := addW(addIdx(field.name, i))
-			values = append(values, quote(field.name)+"="+addPlaceHolder(param))
-			params[param] = field.value
-		}
-		valuesList = append(valuesList, fmt.Sprintf("(%s)", strings.Join(values, " AND ")))
+	if d.softDelete != "" {
+		return d.buildSoftDeleteAllStmt(target)
 	}
-
+	whereClause, params := buildWherePKs(target)
 	sql := fmt.Sprintf("DELETE FROM %s WHERE %s",
 		d.getTableName(),
-		strings.Join(valuesList, " OR "),
+		whereClause,
 	)
 
 	d.log(sql, params)
@@ -30,3 +30,11 @@ func TestDML_buildDeleteAllStmtPointer(t *testing.T) {
 	assert.Equal(t, testRecord2.String, stmt.Params["w_String_1"].(string))
 	assert.Equal(t, testRecord2.Int64, stmt.Params["w_Int64_1"].(int64))
 }
+
+func TestDML_buildDeleteStmtCompositeKey(t *testing.T) {
+	stmt := NewDML("Composite").buildDeleteStmt(testCompositeKeyRecord)
+	assert.Equal(t, "DELETE FROM `Composite` WHERE `A`=@w_A AND `B`=@w_B AND `C`=@w_C", stmt.SQL)
+
+	stmt = NewDML("Composite").buildDeleteAllStmt(&([]testCompositeKey{*testCompositeKeyRecord, *testCompositeKeyRecord}))
+	assert.Equal(t, "DELETE FROM `Composite` WHERE (`A`=@w_A_0 AND `B`=@w_B_0 AND `C`=@w_C_0) OR (`A`=@w_A_1 AND `B`=@w_B_1 AND `C`=@w_C_1)", stmt.SQL)
+}
@@ -15,15 +15,15 @@ import (
 // If you pass a slice of struct, this method will build a statement which insert multiple records in one statement like the following
 // 	INSERT INTO `TableName` (`Column1`, `Column2`) VALUES ('a', 'b'), ('c', 'd'), ...;
 func (d *DML) Insert(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) (rowCount int64, err error) {
-	isStruct, err := validateStructOrStructSliceType(target)
+	isStruct, err := d.validate(target)
 	if err != nil {
 		return 0, err
 	}
 	if isStruct {
-		rowCount, err := tx.Update(ctx, *d.buildInsertStmt(target))
+		rowCount, err := d.update(ctx, tx, d.buildInsertStmt(target))
 		return rowCount, errors.WithStack(err)
 	} else {
-		rowCount, err := tx.Update(ctx, *d.buildInsertAllStmt(target))
+		rowCount, err := d.update(ctx, tx, d.buildInsertAllStmt(target))
 		return rowCount, errors.WithStack(err)
 	}
 }
@@ -34,8 +34,7 @@ func (d *DML) buildInsertStmt(target any) *spanner.Statement {
 	params := map[string]any{}
 	for _, field := range toFields(target) {
 		columns = append(columns, quote(field.name))
-		values = append(values, addPlaceHolder(field.name))
-		params[field.name] = field.value
+		values = append(values, valueExpr(field, field.name, params))
 	}
 
 	sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
@@ -63,9 +62,7 @@ func (d *DML) buildInsertAllStmt(target any) *spanner.Statement {
 			if i == 0 {
 				columns = append(columns, quote(field.name))
 			}
-			param := addIdx(field.name, i)
-			values = append(values, addPlaceHolder(param))
-			params[param] = field.value
+			values = append(values, valueExpr(field, addIdx(field.name, i), params))
 		}
 		valuesList = append(valuesList, "("+strings.Join(values, ", ")+")")
 	}
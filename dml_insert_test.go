@@ -52,3 +52,28 @@ func TestDML_buildInsertAllStmtPointer(t *testing.T) {
 	assert.Equal(t, testRecord2.NullString.StringVal, (stmt.Params["NullString_1"].(spanner.NullString)).StringVal)
 	assert.Equal(t, testRecord2.NullInt64.Int64, (stmt.Params["NullInt64_1"].(spanner.NullInt64)).Int64)
 }
+
+type testCommitTs struct {
+	ID        string     `spanner:"ID" pk:"1"`
+	Name      string     `spanner:"Name"`
+	CreatedAt time.Time  `spanner:"CreatedAt,commit_ts"`
+	UpdatedAt *time.Time `spanner:"UpdatedAt,commit_ts"`
+}
+
+func TestDML_buildInsertStmtCommitTs(t *testing.T) {
+	dml := NewDML("CommitTs")
+	stmt := dml.buildInsertStmt(&testCommitTs{ID: "a", Name: "b"})
+	assert.Equal(t, "INSERT INTO `CommitTs` (`ID`, `Name`, `CreatedAt`, `UpdatedAt`) VALUES (@ID, @Name, PENDING_COMMIT_TIMESTAMP(), PENDING_COMMIT_TIMESTAMP())", stmt.SQL)
+	assert.Equal(t, map[string]any{"ID": "a", "Name": "b"}, stmt.Params)
+
+	stmt = dml.buildInsertAllStmt(&[]testCommitTs{{ID: "a"}, {ID: "b"}})
+	assert.Equal(t, "INSERT INTO `CommitTs` (`ID`, `Name`, `CreatedAt`, `UpdatedAt`) VALUES (@ID_0, @Name_0, PENDING_COMMIT_TIMESTAMP(), PENDING_COMMIT_TIMESTAMP()), (@ID_1, @Name_1, PENDING_COMMIT_TIMESTAMP(), PENDING_COMMIT_TIMESTAMP())", stmt.SQL)
+	assert.Len(t, stmt.Params, 4)
+
+	type invalid struct {
+		UpdatedAt string `spanner:"UpdatedAt,commit_ts"`
+	}
+	var vErr *ValidationError
+	assert.ErrorAs(t, Validate(&invalid{}), &vErr)
+	assert.Equal(t, TagErrInvalidOption, vErr.Errors[0].Kind)
+}
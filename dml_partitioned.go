@@ -0,0 +1,70 @@
+package spnr
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+)
+
+// ErrEmptyWhere is returned by the partitioned DML methods when where clause is empty.
+// Pass "true" explicitly to update or delete all the records in the table.
+var ErrEmptyWhere = errors.New(`where clause is empty, pass "true" to affect all the records`)
+
+// PartitionedUpdate build and execute update statement as partitioned DML like the following.
+//
+//	UPDATE `TableName` SET <set> WHERE <where>
+//
+// Partitioned DML is executed outside of transaction, so it's suitable for table-wide updates like backfills.
+// It returns ErrEmptyWhere if where is empty. Pass "true" to update all the records in the table.
+// The returned row count is a lower bound of the number of the updated records.
+// Note that the statement must be idempotent, since it may be executed more than once for some rows.
+func (d *DML) PartitionedUpdate(ctx context.Context, client *spanner.Client, set string, where string, params map[string]any) (rowCount int64, err error) {
+	if where == "" {
+		return 0, ErrEmptyWhere
+	}
+	sql := fmt.Sprintf("UPDATE %s SET %s WHERE %s", d.getTableName(), set, where)
+	return d.partitionedUpdate(ctx, client, sql, params)
+}
+
+// PartitionedDelete build and execute delete statement as partitioned DML like the following.
+//
+//	DELETE FROM `TableName` WHERE <where>
+//
+// If Options.SoftDeleteColumn is specified, the records are soft deleted by the following statement instead.
+//
+//	UPDATE `TableName` SET `DeletedAt`=PENDING_COMMIT_TIMESTAMP() WHERE (<where>) AND `DeletedAt` IS NULL
+//
+// See PartitionedUpdate for the details.
+func (d *DML) PartitionedDelete(ctx context.Context, client *spanner.Client, where string, params map[string]any) (rowCount int64, err error) {
+	sql, err := d.buildPartitionedDeleteSQL(where)
+	if err != nil {
+		return 0, err
+	}
+	return d.partitionedUpdate(ctx, client, sql, params)
+}
+
+// PartitionedHardDelete deletes the records physically even if Options.SoftDeleteColumn is specified.
+// See PartitionedDelete for the details.
+func (d *DML) PartitionedHardDelete(ctx context.Context, client *spanner.Client, where string, params map[string]any) (rowCount int64, err error) {
+	c := *d
+	c.softDelete = ""
+	return c.PartitionedDelete(ctx, client, where, params)
+}
+
+func (d *DML) buildPartitionedDeleteSQL(where string) (string, error) {
+	if where == "" {
+		return "", ErrEmptyWhere
+	}
+	if d.softDelete != "" {
+		return fmt.Sprintf("UPDATE %s SET %s WHERE (%s) AND %s", d.getTableName(), d.softDeleteSet(true), where, d.softDeleteCond(true)), nil
+	}
+	return fmt.Sprintf("DELETE FROM %s WHERE %s", d.getTableName(), where), nil
+}
+
+func (d *DML) partitionedUpdate(ctx context.Context, client *spanner.Client, sql string, params map[string]any) (int64, error) {
+	d.log(sql, params)
+	rowCount, err := client.PartitionedUpdateWithOptions(ctx, spanner.Statement{SQL: sql, Params: encodeParams(params)}, d.opts.queryOptions())
+	return rowCount, errors.WithStack(err)
+}
@@ -0,0 +1,65 @@
+package spnr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+)
+
+type testLogger struct {
+	logs []string
+}
+
+func (l *testLogger) Printf(format string, v ...any) {
+	l.logs = append(l.logs, fmt.Sprintf(format, v...))
+}
+
+func TestDML_logWithoutParams(t *testing.T) {
+	l := &testLogger{}
+	d := NewDMLWithOptions("Test", &Options{Logger: l, LogEnabled: true})
+	d.log("DELETE FROM `Test` WHERE true", nil)
+	assert.Equal(t, []string{"executing dml... sql:DELETE FROM `Test` WHERE true, params:"}, l.logs)
+}
+
+func TestDML_buildPartitionedDeleteSQL(t *testing.T) {
+	sql, err := testDMLRepository.buildPartitionedDeleteSQL("`String`=@s")
+	assert.Nil(t, err)
+	assert.Equal(t, "DELETE FROM `Test` WHERE `String`=@s", sql)
+
+	soft := NewDMLWithOptions("Singers", &Options{SoftDeleteColumn: "DeletedAt"})
+	sql, err = soft.buildPartitionedDeleteSQL("`Name`=@a OR `Name`=@b")
+	assert.Nil(t, err)
+	assert.Equal(t, "UPDATE `Singers` SET `DeletedAt`=PENDING_COMMIT_TIMESTAMP() WHERE (`Name`=@a OR `Name`=@b) AND `DeletedAt` IS NULL", sql)
+
+	// the empty where clause is rejected before the client is used
+	_, err = soft.buildPartitionedDeleteSQL("")
+	assert.Equal(t, ErrEmptyWhere, err)
+	_, err = testDMLRepository.PartitionedDelete(context.Background(), nil, "", nil)
+	assert.Equal(t, ErrEmptyWhere, err)
+	_, err = testDMLRepository.PartitionedUpdate(context.Background(), nil, "`NullString`=NULL", "", nil)
+	assert.Equal(t, ErrEmptyWhere, err)
+}
+
+func TestDML_PartitionedUpdate(t *testing.T) {
+	ctx := context.Background()
+	_, err := testRepository.ApplyInsertOrUpdate(ctx, dataClient, &([]*Test{testRecord3, testRecord4}))
+	assert.Nil(t, err)
+
+	rowCount, err := testDMLRepository.PartitionedUpdate(ctx, dataClient, "`NullString`=@value", "`String` IN UNNEST(@keys)",
+		map[string]any{"value": "backfilled", "keys": []string{testRecord3.String, testRecord4.String}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), rowCount)
+
+	var fetched Test
+	err = testRepository.Reader(ctx, dataClient.Single()).FindOne(spanner.Key{testRecord3.String, testRecord3.Int64}, &fetched)
+	assert.Nil(t, err)
+	assert.Equal(t, NewNullString("backfilled"), fetched.NullString)
+
+	rowCount, err = testDMLRepository.PartitionedDelete(ctx, dataClient, "`String` IN UNNEST(@keys)",
+		map[string]any{"keys": []string{testRecord3.String, testRecord4.String}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), rowCount)
+}
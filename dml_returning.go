@@ -0,0 +1,181 @@
+package spnr
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
+)
+
+// InsertReturning is basically same as Insert, but it reads back the inserted records with THEN RETURN clause like the following.
+//
+//	INSERT INTO `TableName` (`Column1`, `Column2`) VALUES (@Column1, @Column2) THEN RETURN `Column1`, `Column2`
+//
+// The returned values are mapped into the passed struct (or each struct of the passed slice) in the same way as Reader.Query.
+// It's useful to read the values set by Spanner like default values and generated columns.
+// The columns with commit_ts option are not read back, since Spanner doesn't allow reading PENDING_COMMIT_TIMESTAMP() in the same transaction.
+func (d *DML) InsertReturning(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) (rowCount int64, err error) {
+	isStruct, err := d.validate(target)
+	if err != nil {
+		return 0, err
+	}
+	if isStruct {
+		return d.queryReturning(ctx, tx, d.buildInsertStmt(target), target)
+	}
+	return d.queryReturningAll(ctx, tx, d.buildInsertAllStmt, target)
+}
+
+// UpdateReturning is basically same as Update, but it reads back the updated records with THEN RETURN clause.
+// If you pass a slice of struct, this method will execute update statement for each struct in for loop.
+// See InsertReturning for the details.
+func (d *DML) UpdateReturning(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) (rowCount int64, err error) {
+	isStruct, err := d.validate(target)
+	if err != nil {
+		return 0, err
+	}
+	if isStruct {
+		rowCount, err := d.queryReturning(ctx, tx, d.buildUpdateStmt(target, nil), target)
+		if err != nil {
+			return rowCount, err
+		}
+		return rowCount, checkRowCounts(target, rowCount)
+	}
+	for _, t := range toStructSlice(target) {
+		cnt, err := d.queryReturning(ctx, tx, d.buildUpdateStmt(t, nil), t)
+		if err != nil {
+			return rowCount, err
+		}
+		if err := checkRowCounts(t, cnt); err != nil {
+			return rowCount, err
+		}
+		rowCount += cnt
+	}
+	return rowCount, nil
+}
+
+// DeleteReturning is basically same as Delete, but it reads back the deleted records with THEN RETURN clause.
+// If you pass a slice of struct, this method will build one statement which deletes all the records.
+// See InsertReturning for the details.
+// If Options.SoftDeleteColumn is specified, the soft delete column is not read back for the same reason as commit timestamps.
+func (d *DML) DeleteReturning(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) (rowCount int64, err error) {
+	isStruct, err := d.validate(target)
+	if err != nil {
+		return 0, err
+	}
+	if isStruct {
+		return d.queryReturning(ctx, tx, d.buildDeleteStmt(target), target, d.softDelete)
+	}
+	return d.queryReturningAll(ctx, tx, d.buildDeleteAllStmt, target, d.softDelete)
+}
+
+// addThenReturn appends THEN RETURN clause for the columns of the struct to the statement.
+// The columns with commit_ts option and the pending columns (written with PENDING_COMMIT_TIMESTAMP() by the statement) are excluded.
+func addThenReturn(stmt *spanner.Statement, tp reflect.Type, pending ...string) spanner.Statement {
+	si := getStructInfo(tp)
+	quoted := make([]string, 0, len(si.columns))
+	for _, f := range si.fields {
+		if f.opts.commitTs || containsFold(pending, f.name) {
+			continue
+		}
+		quoted = append(quoted, quote(f.name))
+	}
+	return spanner.Statement{SQL: stmt.SQL + " THEN RETURN " + strings.Join(quoted, ", "), Params: stmt.Params}
+}
+
+// queryReturning executes the statement for a struct and maps the returned row into the struct.
+// The struct is left unchanged if no rows are returned (e.g. the record to update doesn't exist.)
+func (d *DML) queryReturning(ctx context.Context, tx *spanner.ReadWriteTransaction, stmt *spanner.Statement, target any, pending ...string) (int64, error) {
+	iter := tx.QueryWithOptions(ctx, addThenReturn(stmt, reflect.TypeOf(target).Elem(), pending...), d.opts.queryOptions())
+	defer iter.Stop()
+
+	var rowCount int64
+	for {
+		row, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			return rowCount, nil
+		}
+		if err != nil {
+			return rowCount, errors.WithStack(err)
+		}
+		if err := decodeRow(row, target); err != nil {
+			return rowCount, errors.WithStack(err)
+		}
+		rowCount++
+	}
+}
+
+// queryReturningAll executes the statement built by build for a slice of struct, and maps the returned rows into the structs having the same primary key.
+// Since the order of the returned rows is not guaranteed, the rows are matched with the structs by primary key.
+func (d *DML) queryReturningAll(ctx context.Context, tx *spanner.ReadWriteTransaction, build func(any) *spanner.Statement, target any, pending ...string) (int64, error) {
+	structType, mapRow, err := returningMapper(target)
+	if err != nil {
+		return 0, err
+	}
+	stmt := build(target)
+
+	iter := tx.QueryWithOptions(ctx, addThenReturn(stmt, structType, pending...), d.opts.queryOptions())
+	defer iter.Stop()
+
+	var rowCount int64
+	for {
+		row, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			return rowCount, nil
+		}
+		if err != nil {
+			return rowCount, errors.WithStack(err)
+		}
+		if err := mapRow(row); err != nil {
+			return rowCount, err
+		}
+		rowCount++
+	}
+}
+
+// returningMapper returns the struct type of the slice and the function which maps the returned row into the struct having the same primary key.
+// Only the returned columns are set, so the other fields (e.g. commit timestamps) are left unchanged like queryReturning.
+// It returns an error if the slice contains nil.
+func returningMapper(target any) (reflect.Type, func(*spanner.Row) error, error) {
+	slice := reflect.ValueOf(target).Elem()
+	structType := slice.Type().Elem()
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+
+	elems := map[string]reflect.Value{}
+	for i := 0; i < slice.Len(); i++ {
+		e := slice.Index(i)
+		if e.Kind() == reflect.Ptr {
+			if e.IsNil() {
+				return nil, nil, errors.Errorf("element %d of %s is nil", i, slice.Type())
+			}
+		} else {
+			e = e.Addr()
+		}
+		elems[toKey(e).String()] = e
+	}
+
+	return structType, func(row *spanner.Row) error {
+		returned := reflect.New(structType)
+		if err := decodeRow(row, returned.Interface()); err != nil {
+			return errors.WithStack(err)
+		}
+		e, ok := elems[toKey(returned).String()]
+		if !ok {
+			return nil
+		}
+		return errors.WithStack(decodeRow(row, e.Interface()))
+	}, nil
+}
+
+func containsFold(columns []string, column string) bool {
+	for _, c := range columns {
+		if strings.EqualFold(c, column) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package spnr
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddThenReturn(t *testing.T) {
+	stmt := addThenReturn(testDMLRepository.buildDeleteStmt(testRecord1), reflect.TypeOf(testCompositeKey{}))
+	assert.Equal(t, "DELETE FROM `Test` WHERE `String`=@w_String AND `Int64`=@w_Int64 THEN RETURN `C`, `Value`, `A`, `B`", stmt.SQL)
+	assert.Equal(t, testRecord1.String, stmt.Params["w_String"])
+
+	// the columns written with PENDING_COMMIT_TIMESTAMP() can't be read back in the same transaction
+	dml := NewDML("CommitTs")
+	stmt = addThenReturn(dml.buildInsertStmt(&testCommitTs{ID: "a", Name: "b"}), reflect.TypeOf(testCommitTs{}))
+	assert.Equal(t, "INSERT INTO `CommitTs` (`ID`, `Name`, `CreatedAt`, `UpdatedAt`) VALUES (@ID, @Name, PENDING_COMMIT_TIMESTAMP(), PENDING_COMMIT_TIMESTAMP()) THEN RETURN `ID`, `Name`", stmt.SQL)
+	stmt = addThenReturn(dml.buildUpdateStmt(&testCommitTs{ID: "a", Name: "b"}, nil), reflect.TypeOf(testCommitTs{}))
+	assert.True(t, strings.HasSuffix(stmt.SQL, " THEN RETURN `ID`, `Name`"))
+
+	type softDeleted struct {
+		ID        string           `spanner:"ID" pk:"1"`
+		DeletedAt spanner.NullTime `spanner:"DeletedAt"`
+	}
+	soft := NewDMLWithOptions("Singers", &Options{SoftDeleteColumn: "deletedAt"})
+	stmt = addThenReturn(soft.buildDeleteStmt(&softDeleted{ID: "a"}), reflect.TypeOf(softDeleted{}), soft.softDelete)
+	assert.Equal(t, "UPDATE `Singers` SET `deletedAt`=PENDING_COMMIT_TIMESTAMP() WHERE `ID`=@w_ID AND `deletedAt` IS NULL THEN RETURN `ID`", stmt.SQL)
+}
+
+func TestReturningMapper(t *testing.T) {
+	createdAt := time.Now()
+	records := []*testCommitTs{{ID: "a", Name: "a", CreatedAt: createdAt}, {ID: "b", Name: "b", CreatedAt: createdAt}}
+	structType, mapRow, err := returningMapper(&records)
+	assert.Nil(t, err)
+	assert.Equal(t, reflect.TypeOf(testCommitTs{}), structType)
+
+	// only the returned columns are set, and the rows are matched by primary key
+	row, err := spanner.NewRow([]string{"ID", "Name"}, []any{"b", "returned"})
+	assert.Nil(t, err)
+	assert.Nil(t, mapRow(row))
+	assert.Equal(t, "a", records[0].Name)
+	assert.Equal(t, "returned", records[1].Name)
+	assert.Equal(t, createdAt, records[1].CreatedAt)
+
+	values := []testCommitTs{{ID: "a", CreatedAt: createdAt}}
+	_, mapRow, err = returningMapper(&values)
+	assert.Nil(t, err)
+	row, err = spanner.NewRow([]string{"ID", "Name"}, []any{"a", "returned"})
+	assert.Nil(t, err)
+	assert.Nil(t, mapRow(row))
+	assert.Equal(t, testCommitTs{ID: "a", Name: "returned", CreatedAt: createdAt}, values[0])
+
+	_, _, err = returningMapper(&[]*testCommitTs{nil})
+	assert.EqualError(t, err, "element 0 of []*spnr.testCommitTs is nil")
+}
+
+func TestDML_InsertReturning(t *testing.T) {
+	_, err := dataClient.ReadWriteTransaction(context.Background(), func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		records := []Test{*testRecord3, *testRecord4}
+		rowCount, err := testDMLRepository.InsertReturning(ctx, tx, &records)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), rowCount)
+		assert.Equal(t, testRecord3.String, records[0].String)
+		assert.Equal(t, testRecord4.String, records[1].String)
+
+		updated := *testRecord3
+		updated.NullString = NewNullString("updated")
+		rowCount, err = testDMLRepository.UpdateReturning(ctx, tx, &updated)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), rowCount)
+		assert.Equal(t, NewNullString("updated"), updated.NullString)
+
+		var deleted []*Test
+		for _, r := range records {
+			deleted = append(deleted, &Test{String: r.String, Int64: r.Int64})
+		}
+		rowCount, err = testDMLRepository.DeleteReturning(ctx, tx, &deleted)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), rowCount)
+		assert.Equal(t, NewNullString("updated"), deleted[0].NullString)
+		assert.Equal(t, testRecord4.Float64, deleted[1].Float64)
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -3,7 +3,6 @@ package spnr
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"strings"
 
 	"cloud.google.com/go/spanner"
@@ -12,49 +11,107 @@ import (
 
 // Update build and execute update statement from the passed struct.
 // You can pass either a struct or slice of struct to target.
-// If you pass a slice of struct, this method will call update statement in for loop.
+// If you pass a slice of struct, this method will build update statement for each struct and execute them in one batch (see UpdateBatch.)
+// If the struct has version option (e.g. `spanner:"Version,version"`), the version is checked in where clause and incremented,
+// and ErrStaleVersion is returned when the record is not updated. Note that the version of the passed struct is not changed.
 func (d *DML) Update(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) (rowCount int64, err error) {
-	isStruct, err := validateStructOrStructSliceType(target)
+	isStruct, err := d.validate(target)
 	if err != nil {
 		return 0, err
 	}
 	if isStruct {
-		rowCount, err := tx.Update(ctx, *d.buildUpdateStmt(target, nil))
-		return rowCount, errors.WithStack(err)
+		rowCount, err := d.update(ctx, tx, d.buildUpdateStmt(target, nil))
+		if err != nil {
+			return rowCount, errors.WithStack(err)
+		}
+		return rowCount, checkRowCounts(target, rowCount)
 	} else {
-		rowCount, err := d.updateAll(ctx, tx, target)
-		return rowCount, errors.WithStack(err)
+		rowCounts, err := d.batchUpdate(ctx, tx, d.buildUpdateStmts(toStructSlice(target), nil))
+		if err != nil {
+			return sum(rowCounts), err
+		}
+		return sum(rowCounts), checkRowCounts(target, rowCounts...)
 	}
 }
 
-func (d *DML) updateAll(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) (rowCount int64, err error) {
-	slice := reflect.ValueOf(target).Elem()
-	for i := 0; i < slice.Len(); i++ {
-		cnt, err := tx.Update(ctx, *d.buildUpdateStmt(slice.Index(i).Addr().Interface(), nil))
-		if err != nil {
-			return 0, err
-		}
-		rowCount += cnt
+// UpdateBatch is basically same as Update, but it returns the row count of each statement.
+// The statements are executed by spanner.ReadWriteTransaction.BatchUpdate, so only one round trip is required for a slice of struct.
+// If a statement fails, *BatchError containing the index of the failed statement is returned.
+func (d *DML) UpdateBatch(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) (rowCounts []int64, err error) {
+	isStruct, err := d.validate(target)
+	if err != nil {
+		return nil, err
+	}
+	var stmts []spanner.Statement
+	if isStruct {
+		stmts = d.buildUpdateStmts([]any{target}, nil)
+	} else {
+		stmts = d.buildUpdateStmts(toStructSlice(target), nil)
 	}
-	return rowCount, nil
+	rowCounts, err = d.batchUpdate(ctx, tx, stmts)
+	if err != nil {
+		return rowCounts, err
+	}
+	return rowCounts, checkRowCounts(target, rowCounts...)
 }
 
 // UpdateColumns build and execute update statement from the passed column names and struct.
 // You can specify the columns to update.
 // Also, you can pass either a struct or slice of struct to target.
-// If you pass a slice of struct, this method will call update statement in for loop.
+// If you pass a slice of struct, this method will build update statement for each struct and execute them in one batch (see UpdateColumnsBatch.)
+// The columns are matched case-insensitively, and an error is returned if any of them is not mapped to the struct.
 func (d *DML) UpdateColumns(ctx context.Context, tx *spanner.ReadWriteTransaction, columns []string, target any) (rowCount int64, err error) {
-	isStruct, err := validateStructOrStructSliceType(target)
+	isStruct, err := d.validate(target)
 	if err != nil {
 		return 0, err
 	}
+	if err := validateColumns(target, columns); err != nil {
+		return 0, err
+	}
+	if isStruct {
+		rowCount, err := d.update(ctx, tx, d.buildUpdateStmt(target, columns))
+		if err != nil {
+			return rowCount, errors.WithStack(err)
+		}
+		return rowCount, checkRowCounts(target, rowCount)
+	} else {
+		rowCounts, err := d.batchUpdate(ctx, tx, d.buildUpdateStmts(toStructSlice(target), columns))
+		if err != nil {
+			return sum(rowCounts), err
+		}
+		return sum(rowCounts), checkRowCounts(target, rowCounts...)
+	}
+}
+
+// UpdateColumnsBatch is basically same as UpdateColumns, but it returns the row count of each statement.
+// See UpdateBatch for the details.
+func (d *DML) UpdateColumnsBatch(ctx context.Context, tx *spanner.ReadWriteTransaction, columns []string, target any) (rowCounts []int64, err error) {
+	isStruct, err := d.validate(target)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateColumns(target, columns); err != nil {
+		return nil, err
+	}
+	var stmts []spanner.Statement
 	if isStruct {
-		rowCount, err := tx.Update(ctx, *d.buildUpdateStmt(target, columns))
-		return rowCount, errors.WithStack(err)
+		stmts = d.buildUpdateStmts([]any{target}, columns)
 	} else {
-		rowCount, err := d.updateAll(ctx, tx, target)
-		return rowCount, errors.WithStack(err)
+		stmts = d.buildUpdateStmts(toStructSlice(target), columns)
 	}
+	rowCounts, err = d.batchUpdate(ctx, tx, stmts)
+	if err != nil {
+		return rowCounts, err
+	}
+	return rowCounts, checkRowCounts(target, rowCounts...)
+}
+
+func (d *DML) buildUpdateStmts(targets []any, columns []string) []spanner.Statement {
+	stmts := make([]spanner.Statement, 0, len(targets))
+	for _, target := range targets {
+		stmts = append(stmts, *d.buildUpdateStmt(target, columns))
+	}
+	return stmts
 }
 
 func (d *DML) buildUpdateStmt(target any, columns []string) *spanner.Statement {
@@ -67,6 +124,9 @@ func (d *DML) buildUpdateStmt(target any, columns []string) *spanner.Statement {
 		setClause, params = buildSetClause(fields)
 	}
 	whereClause, whereParams := buildWherePK(fields)
+	if versionClause, ok := buildWhereVersion(fields, whereParams); ok {
+		whereClause += " AND " + versionClause
+	}
 	for k, v := range whereParams {
 		params[k] = v
 	}
@@ -86,24 +146,42 @@ func buildSetClause(fields []field) (string, map[string]any) {
 	var columns []string
 	params := map[string]any{}
 	for _, field := range extractNotPks(fields) {
-		columns = append(columns, quote(field.name)+"="+addPlaceHolder(field.name))
-		params[field.name] = field.value
+		if field.version {
+			columns = append(columns, incrementExpr(field))
+			continue
+		}
+		columns = append(columns, quote(field.name)+"="+valueExpr(field, field.name, params))
 	}
 	return strings.Join(columns, ", "), params
 }
 
 func buildSetClauseWithColumns(fields []field, columns []string) (string, map[string]any) {
+	// the columns are matched case-insensitively in the same way as structInfo.lookup
 	fieldsMap := map[string]field{}
 	for _, f := range fields {
-		fieldsMap[f.name] = f
+		fieldsMap[strings.ToLower(f.name)] = f
 	}
 
 	var setColumns []string
 	params := map[string]any{}
+	versionSet := false
 	for _, c := range columns {
-		f := fieldsMap[c]
-		setColumns = append(setColumns, quote(f.name)+"="+addPlaceHolder(f.name))
-		params[f.name] = f.value
+		f, ok := fieldsMap[strings.ToLower(c)]
+		if !ok {
+			continue
+		}
+		if f.version {
+			setColumns = append(setColumns, incrementExpr(f))
+			versionSet = true
+			continue
+		}
+		setColumns = append(setColumns, quote(f.name)+"="+valueExpr(f, f.name, params))
+	}
+	// the version is always incremented even if it's not specified
+	for _, f := range fields {
+		if f.version && !versionSet {
+			setColumns = append(setColumns, incrementExpr(f))
+		}
 	}
 
 	return strings.Join(setColumns, ", "), params
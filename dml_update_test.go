@@ -4,6 +4,7 @@ import (
 	"cloud.google.com/go/spanner"
 	"context"
 	"github.com/stretchr/testify/assert"
+	"reflect"
 	"testing"
 )
 
@@ -21,6 +22,19 @@ func TestDML_buildUpdateStmt(t *testing.T) {
 	assert.Equal(t, testRecord1.NullInt64.Int64, (stmt.Params["NullInt64"].(spanner.NullInt64)).Int64)
 }
 
+func TestDML_buildUpdateStmtColumnsCaseInsensitive(t *testing.T) {
+	stmt := testDMLRepository.buildUpdateStmt(testRecord1, []string{"nullstring", "NULLINT64"})
+	assert.Equal(t, "UPDATE `Test` SET `NullString`=@NullString, `NullInt64`=@NullInt64 WHERE `String`=@w_String AND `Int64`=@w_Int64", stmt.SQL)
+
+	assert.Nil(t, validateColumns(testRecord1, []string{"nullstring"}))
+	assert.ErrorContains(t, validateColumns(&[]Test{}, []string{"NullString", "Unknown"}), "column Unknown")
+
+	_, err := testDMLRepository.UpdateColumns(context.Background(), nil, []string{"Unknown"}, testRecord1)
+	assert.ErrorContains(t, err, "column Unknown")
+	_, err = testDMLRepository.UpdateColumnsBatch(context.Background(), nil, []string{"Unknown"}, &[]*Test{testRecord1})
+	assert.ErrorContains(t, err, "column Unknown")
+}
+
 func TestDML_buildUpdateStmtWithSlice(t *testing.T) {
 	_, err := dataClient.ReadWriteTransaction(context.Background(), func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
 		_, err := testDMLRepository.Insert(ctx, tx, &([]*Test{testRecord3, testRecord4}))
@@ -92,3 +106,64 @@ func TestDML_buildUpdateStmtWithSlicePointer(t *testing.T) {
 	})
 	assert.Nil(t, err)
 }
+
+func TestDML_buildUpdateStmtCommitTs(t *testing.T) {
+	dml := NewDML("CommitTs")
+	stmt := dml.buildUpdateStmt(&testCommitTs{ID: "a", Name: "b"}, nil)
+	assert.Equal(t, "UPDATE `CommitTs` SET `Name`=@Name, `CreatedAt`=PENDING_COMMIT_TIMESTAMP(), `UpdatedAt`=PENDING_COMMIT_TIMESTAMP() WHERE `ID`=@w_ID", stmt.SQL)
+	assert.Equal(t, map[string]any{"Name": "b", "w_ID": "a"}, stmt.Params)
+
+	stmt = dml.buildUpdateStmt(&testCommitTs{ID: "a", Name: "b"}, []string{"UpdatedAt"})
+	assert.Equal(t, "UPDATE `CommitTs` SET `UpdatedAt`=PENDING_COMMIT_TIMESTAMP() WHERE `ID`=@w_ID", stmt.SQL)
+	assert.Equal(t, map[string]any{"w_ID": "a"}, stmt.Params)
+}
+
+type testVersion struct {
+	ID      string `spanner:"ID" pk:"1"`
+	Name    string `spanner:"Name"`
+	Version int64  `spanner:"Version,version"`
+}
+
+func TestDML_buildUpdateStmtVersion(t *testing.T) {
+	dml := NewDML("Versions")
+	stmt := dml.buildUpdateStmt(&testVersion{ID: "a", Name: "b", Version: 3}, nil)
+	assert.Equal(t, "UPDATE `Versions` SET `Name`=@Name, `Version`=`Version`+1 WHERE `ID`=@w_ID AND `Version`=@w_Version", stmt.SQL)
+	assert.Equal(t, map[string]any{"Name": "b", "w_ID": "a", "w_Version": int64(3)}, stmt.Params)
+
+	stmt = dml.buildUpdateStmt(&testVersion{ID: "a", Name: "b", Version: 3}, []string{"Name"})
+	assert.Equal(t, "UPDATE `Versions` SET `Name`=@Name, `Version`=`Version`+1 WHERE `ID`=@w_ID AND `Version`=@w_Version", stmt.SQL)
+
+	assert.Nil(t, checkRowCounts(&testVersion{}, 1))
+	assert.Equal(t, ErrStaleVersion, checkRowCounts(&testVersion{}, 0))
+	assert.Equal(t, ErrStaleVersion, checkRowCounts(&[]*testVersion{}, 1, 0))
+	assert.Nil(t, checkRowCounts(&[]Test{}, 0))
+}
+
+func TestVersionValidation(t *testing.T) {
+	type invalid struct {
+		ID       int64  `spanner:"ID,version" pk:"1"`
+		Name     string `spanner:"Name,version"`
+		Version  int64  `spanner:"Version,version"`
+		Version2 int64  `spanner:"Version2,version"`
+	}
+	var vErr *ValidationError
+	assert.ErrorAs(t, Validate(&invalid{}), &vErr)
+	assert.Len(t, vErr.Errors, 3)
+	for _, te := range vErr.Errors {
+		assert.Equal(t, TagErrInvalidVersion, te.Kind)
+	}
+	assert.Equal(t, "Version", getStructInfo(reflect.TypeOf(invalid{})).version.name)
+
+	// the invalid version option is reported without StrictValidation, since the struct would lose the optimistic locking
+	type nullVersion struct {
+		ID      int64             `spanner:"ID" pk:"1"`
+		Version spanner.NullInt64 `spanner:"Version,version"`
+	}
+	assert.ErrorAs(t, Validate(&nullVersion{}), &vErr)
+	assert.Equal(t, TagErrInvalidVersion, vErr.Errors[0].Kind)
+	_, err := NewDML("Test").Update(context.Background(), nil, &nullVersion{ID: 1})
+	assert.ErrorAs(t, err, &vErr)
+	assert.Equal(t, TagErrInvalidVersion, vErr.Errors[0].Kind)
+	_, err = NewDML("Test").Update(context.Background(), nil, &[]nullVersion{{ID: 1}})
+	assert.ErrorAs(t, err, &vErr)
+}
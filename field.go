@@ -1,26 +1,285 @@
 package spnr
 
 import (
+	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
 )
 
 const (
-	tagColumnName = "spanner"
-	tagPkOrder    = "pk"
-	noPk          = -1
+	tagColumnName  = "spanner"
+	tagPkOrder     = "pk"
+	tagPkDesc      = "desc"
+	tagPkAsc       = "asc"
+	tagOptCodec    = "codec"
+	tagOptJSON     = "json"
+	tagOptCommitTs = "commit_ts"
+	tagOptVersion  = "version"
+	noPk           = -1
 )
 
 type field struct {
 	name    string
 	value   any
 	pkOrder int
+	pkDesc  bool
+	// commitTs means the column is written with the commit timestamp instead of the value.
+	commitTs bool
+	// version means the column is the version for optimistic concurrency control.
+	version bool
 }
 
 func (f *field) isPk() bool {
 	return f.pkOrder != noPk
 }
 
+// fieldInfo is the metadata of a struct field mapped to a column.
+type fieldInfo struct {
+	name    string
+	index   []int
+	typ     reflect.Type
+	pkOrder int
+	pkDesc  bool
+	opts    tagOptions
+}
+
+// tagOptions is the options following the column name in spanner tag (e.g. `spanner:"Status,codec=enum"`).
+type tagOptions struct {
+	codec string
+	// json marshals the field into JSON column, and unmarshals JSON column into the field.
+	json bool
+	// commitTs writes the commit timestamp into the column instead of the field value.
+	commitTs bool
+	// version makes the updates check and increment the column (e.g. `spanner:"Version,version"`).
+	version bool
+}
+
+// structInfo is the metadata of a struct type, which is computed once per type and cached in structInfoCache.
+type structInfo struct {
+	fields  []fieldInfo
+	pks     []fieldInfo
+	columns []string
+	byName  map[string]int
+	// version is the field with version option, or nil if the struct doesn't have it.
+	version *fieldInfo
+	err     *ValidationError
+	// untagged caches the results of lookupUntagged.
+	untagged sync.Map // map[string]*fieldInfo
+}
+
+var structInfoCache sync.Map // map[reflect.Type]*structInfo
+
+func getStructInfo(tp reflect.Type) *structInfo {
+	if si, ok := structInfoCache.Load(tp); ok {
+		return si.(*structInfo)
+	}
+	si, _ := structInfoCache.LoadOrStore(tp, newStructInfo(tp))
+	return si.(*structInfo)
+}
+
+// columnField is a field mapped to a column found in the struct including the embedded structs.
+type columnField struct {
+	sf     reflect.StructField
+	path   string
+	column string
+	opts   tagOptions
+	depth  int
+}
+
+func newStructInfo(tp reflect.Type) *structInfo {
+	si := &structInfo{byName: map[string]int{}}
+	var errs []*TagError
+	cfs := collectColumnFields(tp, nil, "", 0, map[reflect.Type]bool{}, &errs)
+
+	// like promoted fields of Go, the shallowest field shadows the deeper ones having the same column name
+	depths := map[string][]columnField{}
+	for _, cf := range cfs {
+		key := strings.ToLower(cf.column)
+		if len(depths[key]) == 0 || cf.depth < depths[key][0].depth {
+			depths[key] = []columnField{cf}
+		} else if cf.depth == depths[key][0].depth {
+			depths[key] = append(depths[key], cf)
+		}
+	}
+
+	pks := map[int][]string{}
+	versionIdx := -1
+	for _, cf := range cfs {
+		key := strings.ToLower(cf.column)
+		if cf.depth != depths[key][0].depth {
+			continue
+		}
+		if _, exists := si.byName[key]; exists {
+			kind := TagErrDuplicateColumn
+			if cf.depth > 0 {
+				kind = TagErrAmbiguousColumn
+			}
+			errs = append(errs, &TagError{Field: cf.path, Kind: kind, Detail: cf.column})
+			continue
+		}
+		si.byName[key] = len(si.fields)
+		if cf.opts.codec == "" && !cf.opts.json && !hasTypeCodec(cf.sf.Type) && !isSupportedType(cf.sf.Type) {
+			errs = append(errs, &TagError{Field: cf.path, Kind: TagErrUnsupportedType, Detail: cf.sf.Type.String()})
+		}
+		if cf.opts.commitTs && !isTimestampType(cf.sf.Type) {
+			errs = append(errs, &TagError{Field: cf.path, Kind: TagErrInvalidOption, Detail: fmt.Sprintf("%s option requires time.Time, *time.Time or spanner.NullTime but got %s", tagOptCommitTs, cf.sf.Type)})
+		}
+		pkOrder, pkDesc, _ := getPkOrder(cf.sf)
+		if cf.opts.version {
+			if detail := validateVersion(cf, pkOrder, versionIdx); detail != "" {
+				errs = append(errs, &TagError{Field: cf.path, Kind: TagErrInvalidVersion, Detail: detail})
+				cf.opts.version = false
+			} else {
+				versionIdx = len(si.fields)
+			}
+		}
+		if pkOrder != noPk {
+			pks[pkOrder] = append(pks[pkOrder], cf.path)
+		}
+		si.fields = append(si.fields, fieldInfo{
+			name:    cf.column,
+			index:   cf.sf.Index,
+			typ:     cf.sf.Type,
+			pkOrder: pkOrder,
+			pkDesc:  pkDesc,
+			opts:    cf.opts,
+		})
+		si.columns = append(si.columns, cf.column)
+	}
+	for _, f := range si.fields {
+		if f.pkOrder != noPk {
+			si.pks = append(si.pks, f)
+		}
+	}
+	if versionIdx >= 0 {
+		si.version = &si.fields[versionIdx]
+	}
+	sort.SliceStable(si.pks, func(i, j int) bool {
+		return si.pks[i].pkOrder < si.pks[j].pkOrder
+	})
+	errs = append(errs, validatePkOrders(pks)...)
+	if len(errs) > 0 {
+		si.err = &ValidationError{Type: tp.String(), Errors: errs}
+	}
+	return si
+}
+
+// collectColumnFields returns the fields mapped to columns in the declaration order.
+// The fields of anonymous embedded structs (or pointers of them) without spanner tag are flattened recursively.
+// The index of the returned fields is the index sequence from the root struct.
+func collectColumnFields(tp reflect.Type, index []int, prefix string, depth int, visiting map[reflect.Type]bool, errs *[]*TagError) []columnField {
+	visiting[tp] = true
+	defer delete(visiting, tp)
+
+	var cfs []columnField
+	for i := 0; i < tp.NumField(); i++ {
+		sf := tp.Field(i)
+		sf.Index = append(append([]int{}, index...), i)
+		path := prefix + sf.Name
+
+		if sf.Anonymous {
+			if _, tagged := sf.Tag.Lookup(tagColumnName); !tagged {
+				if et, ok := embeddedStructOf(sf); ok && !visiting[et] {
+					cfs = append(cfs, collectColumnFields(et, sf.Index, path+".", depth+1, visiting, errs)...)
+				}
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		pkOrder, _, err := getPkOrder(sf)
+		if err != nil {
+			*errs = append(*errs, &TagError{Field: path, Kind: TagErrInvalidPk, Detail: err.Error()})
+		}
+		name, ok := getColumnName(sf)
+		if !ok {
+			if pkOrder != noPk {
+				*errs = append(*errs, &TagError{Field: path, Kind: TagErrPkWithoutColumn})
+			}
+			continue
+		}
+		opts, err := getTagOptions(sf)
+		if err != nil {
+			*errs = append(*errs, &TagError{Field: path, Kind: TagErrInvalidOption, Detail: err.Error()})
+		}
+		cfs = append(cfs, columnField{sf: sf, path: path, column: name, opts: opts, depth: depth})
+	}
+	return cfs
+}
+
+// embeddedStructOf returns the struct type of the embedded field to be flattened.
+// The pointer of unexported struct is not flattened since it cannot be allocated on reads.
+func embeddedStructOf(sf reflect.StructField) (reflect.Type, bool) {
+	tp := sf.Type
+	if tp.Kind() == reflect.Ptr {
+		if !sf.IsExported() {
+			return nil, false
+		}
+		tp = tp.Elem()
+	}
+	return tp, tp.Kind() == reflect.Struct
+}
+
+// fieldByIndex returns the value of the field.
+// If an embedded pointer on the way is nil, the zero value of the field is returned.
+func fieldByIndex(val reflect.Value, f fieldInfo) reflect.Value {
+	v, err := val.FieldByIndexErr(f.index)
+	if err != nil {
+		return reflect.Zero(f.typ)
+	}
+	return v
+}
+
+// fieldByIndexAlloc returns the settable field allocating the nil embedded pointers on the way.
+func fieldByIndexAlloc(val reflect.Value, f fieldInfo) reflect.Value {
+	for i, x := range f.index {
+		if i > 0 && val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				val.Set(reflect.New(val.Type().Elem()))
+			}
+			val = val.Elem()
+		}
+		val = val.Field(x)
+	}
+	return val
+}
+
+// lookup returns the field mapped to the passed column name ignoring case.
+func (si *structInfo) lookup(column string) (fieldInfo, bool) {
+	i, ok := si.byName[strings.ToLower(column)]
+	if !ok {
+		return fieldInfo{}, false
+	}
+	return si.fields[i], true
+}
+
+// lookupUntagged returns the exported field without spanner tag whose name matches the column case-insensitively.
+// The rows are mapped into such fields like spanner.Row.ToStruct (e.g. the result structs of the queries), though they are never written.
+func (si *structInfo) lookupUntagged(tp reflect.Type, column string) (fieldInfo, bool) {
+	key := strings.ToLower(column)
+	cached, ok := si.untagged.Load(key)
+	if !ok {
+		var f *fieldInfo
+		sf, found := tp.FieldByNameFunc(func(name string) bool { return strings.EqualFold(name, column) })
+		if _, tagged := sf.Tag.Lookup(tagColumnName); found && !tagged && sf.IsExported() {
+			f = &fieldInfo{name: sf.Name, index: sf.Index, typ: sf.Type, pkOrder: noPk}
+		}
+		cached, _ = si.untagged.LoadOrStore(key, f)
+	}
+	f := cached.(*fieldInfo)
+	if f == nil {
+		return fieldInfo{}, false
+	}
+	return *f, true
+}
+
 func toFields(target any) []field {
 	return structValToFields(reflect.ValueOf(target).Elem())
 }
@@ -29,31 +288,159 @@ func structValToFields(val reflect.Value) []field {
 	if val.Kind() == reflect.Ptr {
 		val = val.Elem()
 	}
-	tp := val.Type()
-	var v []field
-	for i := 0; i < val.NumField(); i++ {
-		name := tp.Field(i).Tag.Get(tagColumnName)
-		if name == "" {
+	si := getStructInfo(val.Type())
+	v := make([]field, 0, len(si.fields))
+	for _, f := range si.fields {
+		v = append(v, field{
+			name:     f.name,
+			value:    encodeField(f, fieldByIndex(val, f)),
+			pkOrder:  f.pkOrder,
+			pkDesc:   f.pkDesc,
+			commitTs: f.opts.commitTs,
+			version:  f.opts.version,
+		})
+	}
+	return v
+}
+
+// toValues returns the values of the fields in the same order as structInfo.columns to be written by mutations.
+func toValues(target any) []any {
+	val := reflect.ValueOf(target).Elem()
+	si := getStructInfo(val.Type())
+	values := make([]any, 0, len(si.fields))
+	for _, f := range si.fields {
+		values = append(values, mutationValue(f, val))
+	}
+	return values
+}
+
+// toKey returns the primary key of the struct in pk order.
+func toKey(val reflect.Value) spanner.Key {
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	si := getStructInfo(val.Type())
+	key := make(spanner.Key, 0, len(si.pks))
+	for _, f := range si.pks {
+		key = append(key, encodeField(f, fieldByIndex(val, f)))
+	}
+	return key
+}
+
+// toColumnValues returns the values of the specified columns to be written by mutations.
+// If the struct doesn't have the column, nil is returned for it.
+func toColumnValues(target any, columns []string) []any {
+	val := reflect.ValueOf(target).Elem()
+	si := getStructInfo(val.Type())
+	values := make([]any, 0, len(columns))
+	for _, c := range columns {
+		f, ok := si.lookup(c)
+		if !ok {
+			values = append(values, nil)
 			continue
 		}
-		f := field{
-			name:    name,
-			value:   val.Field(i).Interface(),
-			pkOrder: getPkOrder(tp.Field(i)),
+		values = append(values, mutationValue(f, val))
+	}
+	return values
+}
+
+// mutationValue returns the value of the field written by mutations.
+// spanner.CommitTimestamp is written for the field with commit_ts option.
+func mutationValue(f fieldInfo, val reflect.Value) any {
+	if f.opts.commitTs {
+		return spanner.CommitTimestamp
+	}
+	return encodeField(f, fieldByIndex(val, f))
+}
+
+// validateVersion returns the problem of the field with version option, or empty string if it's valid.
+// The version must be an integer column which is not a part of the primary key, and a struct can have only one version.
+func validateVersion(cf columnField, pkOrder int, versionIdx int) string {
+	switch {
+	case cf.sf.Type.Kind() != reflect.Int64 && cf.sf.Type.Kind() != reflect.Int:
+		return fmt.Sprintf("%s option requires int64 or int but got %s", tagOptVersion, cf.sf.Type)
+	case pkOrder != noPk:
+		return fmt.Sprintf("%s option cannot be used for primary key", tagOptVersion)
+	case cf.opts.commitTs || cf.opts.json || cf.opts.codec != "":
+		return fmt.Sprintf("%s option cannot be used with the other options", tagOptVersion)
+	case versionIdx >= 0:
+		return fmt.Sprintf("%s option can be used only once in a struct", tagOptVersion)
+	}
+	return ""
+}
+
+// isTimestampType reports whether the type can hold the commit timestamp.
+func isTimestampType(tp reflect.Type) bool {
+	return tp == timeType || tp == reflect.PointerTo(timeType) || tp == reflect.TypeOf(spanner.NullTime{})
+}
+
+// getColumnName returns the column name mapped to the field.
+// Only the fields with spanner tag are mapped, and "-" means the field is ignored.
+// If the tag has no name (e.g. `spanner:""`), the field name is used like the spanner client does.
+func getColumnName(s reflect.StructField) (string, bool) {
+	tag, ok := s.Tag.Lookup(tagColumnName)
+	if !ok {
+		return "", false
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		return s.Name, true
+	}
+	return name, true
+}
+
+// getTagOptions parses the options following the column name in spanner tag.
+// e.g. `spanner:"Status,codec=enum"`, `spanner:"Payload,json"`, `spanner:"UpdatedAt,commit_ts"`, `spanner:"Version,version"`
+func getTagOptions(s reflect.StructField) (tagOptions, error) {
+	var opts tagOptions
+	for _, opt := range strings.Split(s.Tag.Get(tagColumnName), ",")[1:] {
+		key, value, _ := strings.Cut(opt, "=")
+		switch key {
+		case tagOptCodec:
+			if value == "" {
+				return opts, errors.Errorf("%s option requires the name of codec", tagOptCodec)
+			}
+			opts.codec = value
+		case tagOptJSON:
+			opts.json = true
+		case tagOptCommitTs:
+			opts.commitTs = true
+		case tagOptVersion:
+			opts.version = true
+		default:
+			return opts, errors.Errorf("unknown option %s", opt)
 		}
-		v = append(v, f)
 	}
-	return v
+	if opts.json && opts.codec != "" {
+		return tagOptions{}, errors.Errorf("%s and %s options cannot be used together", tagOptJSON, tagOptCodec)
+	}
+	return opts, nil
 }
 
-func getPkOrder(s reflect.StructField) int {
+// getPkOrder parses pk tag like `pk:"1"` or `pk:"2,desc"`.
+// desc means the key part is declared as DESC in the primary key of the table.
+func getPkOrder(s reflect.StructField) (pkOrder int, desc bool, err error) {
 	pk := s.Tag.Get(tagPkOrder)
 	if pk == "" {
-		return noPk
+		return noPk, false, nil
 	}
-	pkOrder, err := strconv.Atoi(pk)
+	parts := strings.Split(pk, ",")
+	pkOrder, err = strconv.Atoi(parts[0])
 	if err != nil {
-		panic(err)
+		return noPk, false, errors.Errorf(`pk:"%s" is not an integer`, pk)
+	}
+	for _, opt := range parts[1:] {
+		switch opt {
+		case tagPkDesc:
+			desc = true
+		case tagPkAsc:
+			desc = false
+		default:
+			return noPk, false, errors.Errorf(`pk:"%s" has unknown option %s`, pk, opt)
+		}
 	}
-	return pkOrder
+	return pkOrder, desc, nil
 }
@@ -0,0 +1,154 @@
+package spnr
+
+import (
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStructInfo(t *testing.T) {
+	si := getStructInfo(reflect.TypeOf(Test{}))
+	assert.Len(t, si.fields, 23)
+	assert.Equal(t, "String", si.columns[0])
+	assert.Equal(t, 1, si.fields[0].pkOrder)
+	assert.Equal(t, 2, si.fields[2].pkOrder)
+	assert.Equal(t, noPk, si.fields[1].pkOrder)
+
+	f, ok := si.lookup("nullstring")
+	assert.True(t, ok)
+	assert.Equal(t, "NullString", f.name)
+	_, ok = si.lookup("NotExist")
+	assert.False(t, ok)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Same(t, si, getStructInfo(reflect.TypeOf(Test{})))
+		}()
+	}
+	wg.Wait()
+}
+
+// Audit is exported to be embedded as a pointer.
+type Audit struct {
+	CreatedAt time.Time `spanner:"CreatedAt"`
+	UpdatedAt time.Time `spanner:"UpdatedAt"`
+}
+
+type testKey struct {
+	ID string `spanner:"ID" pk:"1"`
+}
+
+type testEmbedded struct {
+	testKey
+	*Audit
+	Name      string    `spanner:"Name"`
+	UpdatedAt time.Time `spanner:"UpdatedAt"` // shadows Audit.UpdatedAt
+}
+
+type testAmbiguous struct {
+	Audit
+	Other struct {
+		CreatedAt time.Time `spanner:"CreatedAt"`
+	}
+	testAuditCopy
+}
+
+type testAuditCopy struct {
+	CreatedAt time.Time `spanner:"CreatedAt"`
+}
+
+func TestGetStructInfo_embedded(t *testing.T) {
+	si := getStructInfo(reflect.TypeOf(testEmbedded{}))
+	assert.Equal(t, []string{"ID", "CreatedAt", "Name", "UpdatedAt"}, si.columns)
+	assert.Len(t, si.pks, 1)
+	assert.Equal(t, []int{0, 0}, si.pks[0].index)
+	assert.Nil(t, si.err)
+
+	f, ok := si.lookup("UpdatedAt")
+	assert.True(t, ok)
+	assert.Equal(t, []int{3}, f.index)
+
+	// nil embedded pointer is written as zero value
+	now := time.Now()
+	e := &testEmbedded{testKey: testKey{ID: "a"}, Name: "n", UpdatedAt: now}
+	assert.Equal(t, []any{"a", time.Time{}, "n", now}, toValues(e))
+	assert.Equal(t, spanner.Key{"a"}, toKey(reflect.ValueOf(e)))
+
+	// embedded pointer is allocated on reads
+	row, err := spanner.NewRow([]string{"ID", "CreatedAt", "Name", "UpdatedAt"}, []any{"b", now, "m", now})
+	assert.Nil(t, err)
+	var decoded testEmbedded
+	assert.Nil(t, decodeRow(row, &decoded))
+	assert.Equal(t, "b", decoded.ID)
+	assert.True(t, now.Equal(decoded.CreatedAt))
+}
+
+func TestGetStructInfo_ambiguous(t *testing.T) {
+	err := validateTags(&testAmbiguous{}, false)
+	var vErr *ValidationError
+	assert.ErrorAs(t, err, &vErr)
+	assert.Len(t, vErr.Errors, 1)
+	assert.Equal(t, TagErrAmbiguousColumn, vErr.Errors[0].Kind)
+	assert.Equal(t, "testAuditCopy.CreatedAt", vErr.Errors[0].Field)
+}
+
+func BenchmarkStructValToFields(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		toFields(testRecord1)
+	}
+}
+
+func BenchmarkBuildInsertOrUpdate(b *testing.B) {
+	targets := make([]any, 1000)
+	for i := range targets {
+		targets[i] = testRecord1
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		testRepository.buildInsertOrUpdate(targets)
+	}
+}
+
+type testPointers struct {
+	String        string      `spanner:"String" pk:"1"`
+	Int64         int64       `spanner:"Int64" pk:"2"`
+	NullString    *string     `spanner:"NullString"`
+	NullInt64     *int64      `spanner:"NullInt64"`
+	NullFloat64   *float64    `spanner:"NullFloat64"`
+	NullNumeric   *big.Rat    `spanner:"NullNumeric"`
+	NullBool      *bool       `spanner:"NullBool"`
+	NullDate      *civil.Date `spanner:"NullDate"`
+	NullTimestamp *time.Time  `spanner:"NullTimestamp"`
+}
+
+func TestPointerFields(t *testing.T) {
+	assert.Nil(t, Validate(testPointers{}))
+
+	s := "a"
+	fields := toFields(&testPointers{String: "id", NullString: &s})
+	assert.Equal(t, &s, fields[2].value)
+	assert.Equal(t, (*int64)(nil), fields[3].value)
+
+	row, err := spanner.NewRow(
+		[]string{"String", "Int64", "NullString", "NullInt64", "NullNumeric", "NullTimestamp"},
+		[]any{"id", int64(1), spanner.NullString{StringVal: "a", Valid: true}, spanner.NullInt64{}, big.NewRat(1, 2), spanner.NullTime{}},
+	)
+	assert.Nil(t, err)
+	var decoded testPointers
+	assert.Nil(t, decodeRow(row, &decoded))
+	assert.Equal(t, "a", *decoded.NullString)
+	assert.Nil(t, decoded.NullInt64)
+	assert.Equal(t, big.NewRat(1, 2), decoded.NullNumeric)
+	assert.Nil(t, decoded.NullTimestamp)
+}
@@ -71,14 +71,24 @@ func ToKeySets(target any) spanner.KeySet {
 	return spanner.KeySetFromKeys(keys...)
 }
 
+// ToKey builds spanner.Key from the fields of the passed struct which have pk tag.
+// The key parts are ordered by the pk tag, so the key can be used for the read operations directly.
+//
+// Example:
+//
+//	type Album struct {
+//		AlbumID  int64  `spanner:"AlbumId" pk:"2"`
+//		SingerID string `spanner:"SingerId" pk:"1"`
+//	}
+//	ToKey(&Album{SingerID: "a", AlbumID: 1}) // spanner.Key{"a", int64(1)}
+func ToKey(target any) spanner.Key {
+	return toKey(reflect.ValueOf(target))
+}
+
 // ToAllColumnNames receives struct and returns the fields that the passed struct has.
 // This method is useful when you build query to select all the fields.
 // Instead of use *(wildcard), you can specify all of the columns using this method.
 // Then you can avoid the risk that failing to map record to struct caused by the mismatch of an order of columns in spanner table and fields in struct.
 func ToAllColumnNames(target any) string {
-	var columnNames []string
-	for _, f := range structValToFields(reflect.ValueOf(target).Elem()) {
-		columnNames = append(columnNames, f.name)
-	}
-	return strings.Join(columnNames, ", ")
+	return strings.Join(getStructInfo(reflect.TypeOf(target).Elem()).columns, ", ")
 }
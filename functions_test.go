@@ -12,3 +12,8 @@ func TestToKeySets(t *testing.T) {
 	actual := ToKeySets([]string{"a", "b", "c"})
 	assert.Equal(t, fmt.Sprintf("%+v", expected), fmt.Sprintf("%+v", actual))
 }
+
+func TestToKey(t *testing.T) {
+	assert.DeepEqual(t, spanner.Key{"a", int64(2), "c"}, ToKey(testCompositeKeyRecord))
+	assert.DeepEqual(t, spanner.Key{testRecord1.String, testRecord1.Int64}, ToKey(*testRecord1))
+}
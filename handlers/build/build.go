@@ -14,8 +14,49 @@ const (
 	FlagNameDatabaseName = "d"
 	FlagNameOut          = "o"
 	FlagNamePackageName  = "n"
+	FlagNamePointer      = "pointer"
+	FlagNameJSON         = "json"
+	FlagNameProto        = "proto"
 )
 
+// options is the options of the generated code.
+type options struct {
+	// pointer makes nullable columns pointer types (e.g. *string) instead of spanner.Null* types.
+	pointer bool
+	// jsonTypes maps "Table.Column" of JSON columns to the Go types (e.g. "github.com/foo/bar/model.Payload").
+	// The columns which are not in the map are generated as spanner.NullJSON.
+	jsonTypes map[string]string
+	// protoPackages maps the fully-qualified names of protobuf packages or types to the Go import paths.
+	// e.g. "examples.music" to "github.com/foo/bar/musicpb"
+	protoPackages map[string]string
+}
+
+// parseProtoPackages parses the values of proto flag like "examples.music=github.com/foo/bar/musicpb".
+func parseProtoPackages(values []string) (map[string]string, error) {
+	res := map[string]string{}
+	for _, v := range values {
+		name, importPath, ok := strings.Cut(v, "=")
+		if !ok || name == "" || importPath == "" {
+			return nil, errors.Errorf("invalid %s flag %s, it must be like proto.package=go/import/path", FlagNameProto, v)
+		}
+		res[name] = importPath
+	}
+	return res, nil
+}
+
+// parseJSONTypes parses the values of json flag like "Singers.Profile=github.com/foo/bar/model.Profile".
+func parseJSONTypes(values []string) (map[string]string, error) {
+	res := map[string]string{}
+	for _, v := range values {
+		column, tp, ok := strings.Cut(v, "=")
+		if !ok || !strings.Contains(column, ".") || tp == "" {
+			return nil, errors.Errorf("invalid %s flag %s, it must be like Table.Column=Type", FlagNameJSON, v)
+		}
+		res[column] = tp
+	}
+	return res, nil
+}
+
 func Run(c *cli.Context) error {
 	out := c.String(FlagNameOut)
 	if _, err := os.Stat(out); errors.Is(err, os.ErrNotExist) {
@@ -30,12 +71,23 @@ func Run(c *cli.Context) error {
 		packageName = "entity"
 	}
 
+	jsonTypes, err := parseJSONTypes(c.StringSlice(FlagNameJSON))
+	if err != nil {
+		return err
+	}
+
+	protoPackages, err := parseProtoPackages(c.StringSlice(FlagNameProto))
+	if err != nil {
+		return err
+	}
+
 	codes, err := generateCode(
 		c.Context,
 		c.String(FlagNameProjectId),
 		c.String(FlagNameInstanceName),
 		c.String(FlagNameDatabaseName),
 		packageName,
+		options{pointer: c.Bool(FlagNamePointer), jsonTypes: jsonTypes, protoPackages: protoPackages},
 	)
 	if err != nil {
 		return err
@@ -49,12 +101,12 @@ func Run(c *cli.Context) error {
 	return nil
 }
 
-func generateCode(ctx context.Context, projectId, instanceName, dbName, packageName string) (map[string][]byte, error) {
+func generateCode(ctx context.Context, projectId, instanceName, dbName, packageName string, opts options) (map[string][]byte, error) {
 	columns, err := fetchColumns(ctx, projectId, instanceName, dbName)
 	if err != nil {
 		return nil, err
 	}
-	return generate(packageName, columns)
+	return generate(packageName, columns, opts)
 }
 
 func writeFile(dirName, tableName string, code []byte) error {
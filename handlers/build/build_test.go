@@ -29,7 +29,7 @@ var (
 )
 
 func TestGenerateCode(t *testing.T) {
-	codes, err := generateCode(context.Background(), projectName, instanceName, databaseName, "entity_test")
+	codes, err := generateCode(context.Background(), projectName, instanceName, databaseName, "entity_test", options{})
 	assert.Nil(t, err)
 	b, err := os.ReadFile("testdata/test1.go")
 	assert.Nil(t, err)
@@ -37,6 +37,63 @@ func TestGenerateCode(t *testing.T) {
 	b, err = os.ReadFile("testdata/test2.go")
 	assert.Nil(t, err)
 	assert.Equal(t, string(b), string(codes["Test2"]))
+	b, err = os.ReadFile("testdata/test3.go")
+	assert.Nil(t, err)
+	assert.Equal(t, string(b), string(codes["Test3"]))
+}
+
+func TestGenerateCodeWithPointer(t *testing.T) {
+	codes, err := generateCode(context.Background(), projectName, instanceName, databaseName, "entity_test", options{pointer: true})
+	assert.Nil(t, err)
+	b, err := os.ReadFile("testdata/test1_pointer.go")
+	assert.Nil(t, err)
+	assert.Equal(t, string(b), string(codes["Test1"]))
+}
+
+func TestGenerateCodeWithJSONTypes(t *testing.T) {
+	jsonTypes, err := parseJSONTypes([]string{"Test3.Payload=github.com/foo/bar/model.Payload", "Test3.NullPayload=*github.com/foo/bar/model.Payload"})
+	assert.Nil(t, err)
+	codes, err := generateCode(context.Background(), projectName, instanceName, databaseName, "entity_test", options{jsonTypes: jsonTypes})
+	assert.Nil(t, err)
+	b, err := os.ReadFile("testdata/test3_json.go")
+	assert.Nil(t, err)
+	assert.Equal(t, string(b), string(codes["Test3"]))
+
+	_, err = parseJSONTypes([]string{"Payload=model.Payload"})
+	assert.NotNil(t, err)
+}
+
+func TestGenerateCodeWithJSONTypesForArray(t *testing.T) {
+	records := map[string][]columnRecord{"Test5": {
+		{TableName: "Test5", ColumnsName: "ID", Nullable: "NO", Type: "INT64"},
+		{TableName: "Test5", ColumnsName: "Payloads", Nullable: "YES", Type: "ARRAY<JSON>"},
+	}}
+	jsonTypes, err := parseJSONTypes([]string{"Test5.Payloads=github.com/foo/bar/model.Payload"})
+	assert.Nil(t, err)
+	_, err = generate("entity_test", buildColumns(records, map[string]map[string]int64{"Test5": {"ID": 1}}, nil), options{jsonTypes: jsonTypes})
+	assert.EqualError(t, err, "Test5.Payloads is not JSON column, --json flag is only for JSON columns")
+}
+
+func TestGenerateCodeWithProto(t *testing.T) {
+	records := map[string][]columnRecord{"Test4": {
+		{TableName: "Test4", ColumnsName: "ID", Nullable: "NO", Type: "INT64"},
+		{TableName: "Test4", ColumnsName: "Info", Nullable: "YES", Type: "PROTO<examples.music.SingerInfo>"},
+		{TableName: "Test4", ColumnsName: "Genre", Nullable: "NO", Type: "ENUM<examples.music.Genre>"},
+		{TableName: "Test4", ColumnsName: "NullGenre", Nullable: "YES", Type: "ENUM<examples.music.Genre>"},
+		{TableName: "Test4", ColumnsName: "Infos", Nullable: "YES", Type: "ARRAY<PROTO<examples.music.SingerInfo>>"},
+		{TableName: "Test4", ColumnsName: "Genres", Nullable: "YES", Type: "ARRAY<ENUM<examples.music.SingerInfo.SubGenre>>"},
+		{TableName: "Test4", ColumnsName: "Album", Nullable: "YES", Type: "PROTO<examples.music.v1.Album>"},
+	}}
+	protoPackages, err := parseProtoPackages([]string{"examples.music=github.com/foo/bar/musicpb", "examples.music.v1.Album=github.com/foo/bar/music/v1;albumpb"})
+	assert.Nil(t, err)
+	codes, err := generate("entity_test", buildColumns(records, map[string]map[string]int64{"Test4": {"ID": 1}}, nil), options{protoPackages: protoPackages})
+	assert.Nil(t, err)
+	b, err := os.ReadFile("testdata/test4_proto.go")
+	assert.Nil(t, err)
+	assert.Equal(t, string(b), string(codes["Test4"]))
+
+	_, err = generate("entity_test", buildColumns(records, nil, nil), options{})
+	assert.NotNil(t, err)
 }
 
 func TestMain(m *testing.M) {
@@ -59,9 +116,9 @@ func TestMain(m *testing.M) {
 
 func initSpannerContainer(ctx context.Context) (testcontainers.Container, error) {
 	req := testcontainers.ContainerRequest{
-		Image:        "gcr.io/cloud-spanner-emulator/emulator:1.3.0",
+		Image:        "gcr.io/cloud-spanner-emulator/emulator:1.5.23",
 		ExposedPorts: []string{"9010/tcp"},
-		WaitingFor:   wait.ForLog("gateway.go:142: gRPC server listening at 0.0.0.0:9010"),
+		WaitingFor:   wait.ForLog("gRPC server listening at 0.0.0.0:9010"),
 	}
 	spannerC, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 		ContainerRequest: req,
@@ -117,11 +174,15 @@ func initDatabase(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	b3, err := os.ReadFile("testdata/test3.sql")
+	if err != nil {
+		return err
+	}
 
 	createDatabaseReq := &databasepb.CreateDatabaseRequest{
 		Parent:          instanceID,
 		CreateStatement: "CREATE DATABASE " + databaseName,
-		ExtraStatements: []string{string(b1), string(b2)},
+		ExtraStatements: []string{string(b1), string(b2), string(b3)},
 	}
 	cdOp, err := adminClient.CreateDatabase(ctx, createDatabaseReq)
 	if err != nil {
@@ -4,7 +4,7 @@ import (
 	"cloud.google.com/go/spanner"
 	"context"
 	"fmt"
-	"github.com/kanjih/go-spnr"
+	spnr "github.com/kanjih/go-spnr/v2"
 	"strings"
 )
 
@@ -28,6 +28,12 @@ const (
 	tpArrayBool
 	tpArrayDate
 	tpArrayTimestamp
+	tpJSON
+	tpArrayJSON
+	tpProto
+	tpEnum
+	tpArrayProto
+	tpArrayEnum
 )
 
 type columnRecord struct {
@@ -37,6 +43,13 @@ type columnRecord struct {
 	Type        string `spanner:"SPANNER_TYPE"`
 }
 
+type columnOptionRecord struct {
+	TableName   string `spanner:"TABLE_NAME"`
+	ColumnsName string `spanner:"COLUMN_NAME"`
+	OptionName  string `spanner:"OPTION_NAME"`
+	OptionValue string `spanner:"OPTION_VALUE"`
+}
+
 type indexColumnRecord struct {
 	TableName   string `spanner:"TABLE_NAME"`
 	ColumnsName string `spanner:"COLUMN_NAME"`
@@ -49,6 +62,10 @@ type column struct {
 	nullable bool
 	isPk     bool
 	pkOrder  int
+	// protoName is the fully-qualified name of PROTO or ENUM type (e.g. examples.music.SingerInfo).
+	protoName string
+	// commitTs means the column has allow_commit_timestamp=true option.
+	commitTs bool
 }
 
 func fetchColumns(ctx context.Context, projectId, instanceName, dbName string) (map[string][]column, error) {
@@ -64,7 +81,11 @@ func fetchColumns(ctx context.Context, projectId, instanceName, dbName string) (
 	if err != nil {
 		return nil, err
 	}
-	return buildColumns(columns, primaryKeys), nil
+	commitTsColumns, err := fetchCommitTsColumns(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return buildColumns(columns, primaryKeys, commitTsColumns), nil
 }
 
 func fetchColumnRecords(ctx context.Context, client *spanner.Client) (map[string][]columnRecord, error) {
@@ -103,19 +124,44 @@ func fetchPrimaryKeys(ctx context.Context, client *spanner.Client) (map[string]m
 	return res, nil
 }
 
-func buildColumns(columnRecords map[string][]columnRecord, pkLists map[string]map[string]int64) map[string][]column {
+// fetchCommitTsColumns returns the columns having allow_commit_timestamp=true option for each table.
+func fetchCommitTsColumns(ctx context.Context, client *spanner.Client) (map[string]map[string]bool, error) {
+	q := "select TABLE_NAME, COLUMN_NAME, OPTION_NAME, OPTION_VALUE from information_schema.COLUMN_OPTIONS where TABLE_SCHEMA = '' and OPTION_NAME = 'allow_commit_timestamp'"
+	var options []columnOptionRecord
+	if err := spnr.New("").Reader(ctx, client.Single()).Query(q, nil, &options); err != nil {
+		return nil, err
+	}
+	res := map[string]map[string]bool{}
+	for _, o := range options {
+		if o.OptionValue != "TRUE" {
+			continue
+		}
+		m, exists := res[o.TableName]
+		if !exists {
+			m = map[string]bool{}
+		}
+		m[o.ColumnsName] = true
+		res[o.TableName] = m
+	}
+	return res, nil
+}
+
+func buildColumns(columnRecords map[string][]columnRecord, pkLists map[string]map[string]int64, commitTsLists map[string]map[string]bool) map[string][]column {
 	res := map[string][]column{}
 	for tableName, columnRecords := range columnRecords {
 		pks := pkLists[tableName]
+		commitTsColumns := commitTsLists[tableName]
 		var columns []column
 		for _, r := range columnRecords {
 			pkOrder, isPk := pks[r.ColumnsName]
 			columns = append(columns, column{
-				name:     r.ColumnsName,
-				tp:       parseType(r.Type),
-				nullable: r.Nullable == "YES",
-				isPk:     isPk,
-				pkOrder:  int(pkOrder),
+				name:      r.ColumnsName,
+				tp:        parseType(r.Type),
+				nullable:  r.Nullable == "YES",
+				isPk:      isPk,
+				pkOrder:   int(pkOrder),
+				protoName: parseProtoName(r.Type),
+				commitTs:  commitTsColumns[r.ColumnsName],
 			})
 		}
 		res[tableName] = columns
@@ -137,6 +183,8 @@ func parseType(tp string) spannerType {
 		return tpDate
 	case "TIMESTAMP":
 		return tpTimestamp
+	case "JSON":
+		return tpJSON
 	case "ARRAY<INT64>":
 		return tpArrayInt64
 	case "ARRAY<FLOAT64>":
@@ -149,6 +197,8 @@ func parseType(tp string) spannerType {
 		return tpArrayDate
 	case "ARRAY<TIMESTAMP>":
 		return tpArrayTimestamp
+	case "ARRAY<JSON>":
+		return tpArrayJSON
 	}
 	if strings.HasPrefix(tp, "STRING") {
 		return tpString
@@ -162,5 +212,30 @@ func parseType(tp string) spannerType {
 	if strings.HasPrefix(tp, "ARRAY<BYTES") {
 		return tpArrayBytes
 	}
+	if strings.HasPrefix(tp, "PROTO<") {
+		return tpProto
+	}
+	if strings.HasPrefix(tp, "ENUM<") {
+		return tpEnum
+	}
+	if strings.HasPrefix(tp, "ARRAY<PROTO<") {
+		return tpArrayProto
+	}
+	if strings.HasPrefix(tp, "ARRAY<ENUM<") {
+		return tpArrayEnum
+	}
 	return tpUndefined
 }
+
+// parseProtoName returns the fully-qualified name in PROTO or ENUM type.
+// e.g. "examples.music.SingerInfo" for "ARRAY<PROTO<examples.music.SingerInfo>>"
+func parseProtoName(tp string) string {
+	if !strings.Contains(tp, "PROTO<") && !strings.Contains(tp, "ENUM<") {
+		return ""
+	}
+	start, end := strings.LastIndex(tp, "<"), strings.Index(tp, ">")
+	if end < start {
+		return ""
+	}
+	return tp[start+1 : end]
+}
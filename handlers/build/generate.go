@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"github.com/iancoleman/strcase"
+	"github.com/pkg/errors"
 	"go/format"
 	"strings"
 	"text/template"
@@ -29,10 +30,14 @@ type tmplValues struct {
 	Fields      []string
 }
 
-func generate(pkgName string, tableNameColumns map[string][]column) (map[string][]byte, error) {
+func generate(pkgName string, tableNameColumns map[string][]column, opts options) (map[string][]byte, error) {
 	res := map[string][]byte{}
 	for tableName, columns := range tableNameColumns {
-		b, err := buildCode(buildTmplValues(pkgName, tableName, columns))
+		v, err := buildTmplValues(pkgName, tableName, columns, opts)
+		if err != nil {
+			return nil, err
+		}
+		b, err := buildCode(v)
 		if err != nil {
 			return nil, err
 		}
@@ -41,11 +46,32 @@ func generate(pkgName string, tableNameColumns map[string][]column) (map[string]
 	return res, nil
 }
 
-func buildTmplValues(pkgName, tableName string, columns []column) tmplValues {
-	var fields []string
+func buildTmplValues(pkgName, tableName string, columns []column, opts options) (tmplValues, error) {
+	var fields, imports []string
 	var containsNullable, containsDate, containsBig, containsTimestamp bool
 	for _, c := range columns {
-		if c.nullable {
+		if jsonType, ok := opts.jsonTypes[tableName+"."+c.name]; ok {
+			if c.tp != tpJSON {
+				// ARRAY<JSON> is not supported, since the json option maps the field to a single JSON value
+				return tmplValues{}, errors.Errorf("%s.%s is not JSON column, --%s flag is only for JSON columns", tableName, c.name, FlagNameJSON)
+			}
+			tp, importPath := parseGoType(jsonType)
+			if importPath != "" {
+				imports = append(imports, fmt.Sprintf("%q", importPath))
+			}
+			fields = append(fields, fmt.Sprintf("%s %s `%s`", strcase.ToCamel(c.name), tp, buildJSONFieldName(c)+buildPk(c)))
+			continue
+		}
+		if c.protoName != "" {
+			tp, importSpec, err := resolveProtoType(c.protoName, opts.protoPackages)
+			if err != nil {
+				return tmplValues{}, errors.Wrapf(err, "failed to resolve the type of %s.%s", tableName, c.name)
+			}
+			imports = append(imports, importSpec)
+			fields = append(fields, fmt.Sprintf("%s %s `%s`", strcase.ToCamel(c.name), buildProtoType(c, tp), buildFieldName(c)+buildPk(c)))
+			continue
+		}
+		if c.nullable && !opts.pointer {
 			if c.tp == tpString || c.tp == tpInt64 || c.tp == tpFloat64 || c.tp == tpNumeric || c.tp == tpBool || c.tp == tpDate || c.tp == tpTimestamp {
 				containsNullable = true
 			}
@@ -56,18 +82,85 @@ func buildTmplValues(pkgName, tableName string, columns []column) tmplValues {
 			containsBig = true
 		} else if c.tp == tpTimestamp || c.tp == tpArrayTimestamp {
 			containsTimestamp = true
+		} else if c.tp == tpJSON || c.tp == tpArrayJSON {
+			containsNullable = true
 		}
-		fields = append(fields, fmt.Sprintf("%s %s `%s`", strcase.ToCamel(c.name), buildType(c), buildFieldName(c)+buildPk(c)))
+		fields = append(fields, fmt.Sprintf("%s %s `%s`", strcase.ToCamel(c.name), buildType(c, opts), buildFieldName(c)+buildPk(c)))
 	}
 	return tmplValues{
 		PackageName: pkgName,
 		StructName:  strcase.ToCamel(tableName),
-		Import:      buildImport(containsNullable, containsDate, containsBig, containsTimestamp),
+		Import:      buildImport(containsNullable, containsDate, containsBig, containsTimestamp, imports...),
 		Fields:      fields,
+	}, nil
+}
+
+// resolveProtoType returns the Go type and the import spec of the fully-qualified protobuf name like "examples.music.SingerInfo".
+// The longest matching name in protoPackages is used, and the nested types are joined with "_" (e.g. SingerInfo_Genre) like protoc-gen-go.
+// The import path can be followed by ";" and the package name like go_package option (e.g. "github.com/foo/bar/music/v1;musicpb").
+func resolveProtoType(name string, protoPackages map[string]string) (tp, importSpec string, err error) {
+	var matched string
+	for pkg := range protoPackages {
+		if (name == pkg || strings.HasPrefix(name, pkg+".")) && len(pkg) > len(matched) {
+			matched = pkg
+		}
 	}
+	if matched == "" {
+		return "", "", errors.Errorf("protobuf type %s is not mapped to Go package, specify it by --%s flag", name, FlagNameProto)
+	}
+	importPath, pkgName, ok := strings.Cut(protoPackages[matched], ";")
+	importSpec = fmt.Sprintf("%q", importPath)
+	if !ok {
+		pkgName = importPath[strings.LastIndex(importPath, "/")+1:]
+	} else {
+		importSpec = pkgName + " " + importSpec
+	}
+	typeName := strings.TrimPrefix(strings.TrimPrefix(name, matched), ".")
+	if typeName == "" {
+		// the type itself is mapped
+		typeName = name[strings.LastIndex(name, ".")+1:]
+	}
+	return pkgName + "." + strings.ReplaceAll(typeName, ".", "_"), importSpec, nil
 }
 
-func buildType(c column) string {
+// buildProtoType returns the Go type of PROTO or ENUM column.
+// Messages are always pointers, and enums are pointers only when the column is nullable.
+func buildProtoType(c column, tp string) string {
+	switch c.tp {
+	case tpProto:
+		return "*" + tp
+	case tpEnum:
+		if c.nullable {
+			return "*" + tp
+		}
+		return tp
+	case tpArrayProto:
+		return "[]*" + tp
+	case tpArrayEnum:
+		return "[]" + tp
+	}
+	return "undefinedType"
+}
+
+// parseGoType splits the type like "*github.com/foo/bar/model.Profile" into "*model.Profile" and "github.com/foo/bar/model".
+// The types without package path (e.g. "map[string]any") are returned as they are.
+func parseGoType(s string) (tp, importPath string) {
+	prefix := s[:len(s)-len(strings.TrimLeft(s, "*[]"))]
+	s = s[len(prefix):]
+	i := strings.LastIndex(s, ".")
+	if i < 0 || strings.ContainsAny(s, "[]{} ") {
+		return prefix + s, ""
+	}
+	importPath = s[:i]
+	return prefix + importPath[strings.LastIndex(importPath, "/")+1:] + s[i:], importPath
+}
+
+func buildType(c column, opts options) string {
+	if c.nullable && opts.pointer {
+		if tp := buildPointerType(c); tp != "" {
+			return tp
+		}
+	}
 	switch c.tp {
 	case tpString:
 		if c.nullable {
@@ -122,14 +215,47 @@ func buildType(c column) string {
 		return "[]civil.Date"
 	case tpArrayTimestamp:
 		return "[]time.Time"
+	case tpJSON:
+		return "spanner.NullJSON"
+	case tpArrayJSON:
+		return "[]spanner.NullJSON"
 	}
 	return "undefinedType"
 }
 
+// buildPointerType returns the pointer type for the nullable column.
+// It returns empty string for the types which can express null without pointer (e.g. []byte).
+func buildPointerType(c column) string {
+	switch c.tp {
+	case tpString:
+		return "*string"
+	case tpInt64:
+		return "*int64"
+	case tpFloat64:
+		return "*float64"
+	case tpNumeric:
+		return "*big.Rat"
+	case tpBool:
+		return "*bool"
+	case tpDate:
+		return "*civil.Date"
+	case tpTimestamp:
+		return "*time.Time"
+	}
+	return ""
+}
+
 func buildFieldName(c column) string {
+	if c.commitTs {
+		return fmt.Sprintf(`spanner:"%s,commit_ts"`, c.name)
+	}
 	return fmt.Sprintf(`spanner:"%s"`, c.name)
 }
 
+func buildJSONFieldName(c column) string {
+	return fmt.Sprintf(`spanner:"%s,json"`, c.name)
+}
+
 func buildPk(c column) string {
 	if !c.isPk {
 		return ""
@@ -137,7 +263,7 @@ func buildPk(c column) string {
 	return fmt.Sprintf(` pk:"%d"`, c.pkOrder)
 }
 
-func buildImport(containsNullable, containsDate, containsBig, containsTimestamp bool) string {
+func buildImport(containsNullable, containsDate, containsBig, containsTimestamp bool, others ...string) string {
 	var imports []string
 	if containsNullable {
 		imports = append(imports, tmplImportSpanner)
@@ -151,6 +277,11 @@ func buildImport(containsNullable, containsDate, containsBig, containsTimestamp
 	if containsTimestamp {
 		imports = append(imports, tmplImportTime)
 	}
+	for _, o := range others {
+		if !contains(imports, o) {
+			imports = append(imports, o)
+		}
+	}
 
 	if len(imports) == 0 {
 		return ""
@@ -169,3 +300,12 @@ func buildCode(v tmplValues) ([]byte, error) {
 	}
 	return format.Source(buf.Bytes())
 }
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
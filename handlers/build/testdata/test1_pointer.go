@@ -0,0 +1,33 @@
+package entity_test
+
+import (
+	"cloud.google.com/go/civil"
+	"math/big"
+	"time"
+)
+
+type Test1 struct {
+	String         string       `spanner:"String" pk:"1"`
+	Bytes          []byte       `spanner:"Bytes"`
+	Int64          int64        `spanner:"Int64" pk:"2"`
+	Float64        float64      `spanner:"Float64"`
+	Numeric        big.Rat      `spanner:"Numeric"`
+	Bool           bool         `spanner:"Bool"`
+	Date           civil.Date   `spanner:"Date"`
+	Timestamp      time.Time    `spanner:"Timestamp"`
+	NullString     *string      `spanner:"NullString"`
+	NullInt64      *int64       `spanner:"NullInt64"`
+	NullFloat64    *float64     `spanner:"NullFloat64"`
+	NullNumeric    *big.Rat     `spanner:"NullNumeric"`
+	NullBool       *bool        `spanner:"NullBool"`
+	NullDate       *civil.Date  `spanner:"NullDate"`
+	NullTimestamp  *time.Time   `spanner:"NullTimestamp"`
+	ArrayString    []string     `spanner:"ArrayString"`
+	ArrayBytes     [][]byte     `spanner:"ArrayBytes"`
+	ArrayInt64     []int64      `spanner:"ArrayInt64"`
+	ArrayFloat64   []float64    `spanner:"ArrayFloat64"`
+	ArrayNumeric   []big.Rat    `spanner:"ArrayNumeric"`
+	ArrayBool      []bool       `spanner:"ArrayBool"`
+	ArrayDate      []civil.Date `spanner:"ArrayDate"`
+	ArrayTimestamp []time.Time  `spanner:"ArrayTimestamp"`
+}
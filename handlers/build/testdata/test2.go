@@ -3,6 +3,7 @@ package entity_test
 import "time"
 
 type Test2 struct {
-	String string    `spanner:"String" pk:"1"`
-	Bytes  time.Time `spanner:"Bytes"`
+	String    string    `spanner:"String" pk:"1"`
+	Bytes     time.Time `spanner:"Bytes"`
+	UpdatedAt time.Time `spanner:"UpdatedAt,commit_ts"`
 }
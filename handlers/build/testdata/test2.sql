@@ -1,4 +1,5 @@
 CREATE TABLE Test2 (
     `String` String(10) NOT NULL,
     `Bytes` Timestamp NOT NULL,
+    `UpdatedAt` Timestamp NOT NULL OPTIONS (allow_commit_timestamp=true),
 ) PRIMARY KEY (String)
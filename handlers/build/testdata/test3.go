@@ -0,0 +1,10 @@
+package entity_test
+
+import "cloud.google.com/go/spanner"
+
+type Test3 struct {
+	Id           string             `spanner:"ID" pk:"1"`
+	Payload      spanner.NullJSON   `spanner:"Payload"`
+	NullPayload  spanner.NullJSON   `spanner:"NullPayload"`
+	ArrayPayload []spanner.NullJSON `spanner:"ArrayPayload"`
+}
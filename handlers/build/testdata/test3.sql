@@ -0,0 +1,6 @@
+CREATE TABLE Test3 (
+    `ID` String(10) NOT NULL,
+    `Payload` JSON NOT NULL,
+    `NullPayload` JSON,
+    `ArrayPayload` ARRAY<JSON>,
+) PRIMARY KEY (ID)
@@ -0,0 +1,13 @@
+package entity_test
+
+import (
+	"cloud.google.com/go/spanner"
+	"github.com/foo/bar/model"
+)
+
+type Test3 struct {
+	Id           string             `spanner:"ID" pk:"1"`
+	Payload      model.Payload      `spanner:"Payload,json"`
+	NullPayload  *model.Payload     `spanner:"NullPayload,json"`
+	ArrayPayload []spanner.NullJSON `spanner:"ArrayPayload"`
+}
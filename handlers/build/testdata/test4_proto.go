@@ -0,0 +1,16 @@
+package entity_test
+
+import (
+	albumpb "github.com/foo/bar/music/v1"
+	"github.com/foo/bar/musicpb"
+)
+
+type Test4 struct {
+	Id        int64                         `spanner:"ID" pk:"1"`
+	Info      *musicpb.SingerInfo           `spanner:"Info"`
+	Genre     musicpb.Genre                 `spanner:"Genre"`
+	NullGenre *musicpb.Genre                `spanner:"NullGenre"`
+	Infos     []*musicpb.SingerInfo         `spanner:"Infos"`
+	Genres    []musicpb.SingerInfo_SubGenre `spanner:"Genres"`
+	Album     *albumpb.Album                `spanner:"Album"`
+}
@@ -18,8 +18,8 @@ func extractPks(fields []field) []field {
 			pks = append(pks, field)
 		}
 	}
-	sort.Slice(pks, func(i, j int) bool {
-		return fields[i].pkOrder > fields[j].pkOrder
+	sort.SliceStable(pks, func(i, j int) bool {
+		return pks[i].pkOrder < pks[j].pkOrder
 	})
 	return pks
 }
@@ -45,6 +45,55 @@ func buildWherePK(fields []field) (string, map[string]any) {
 	return strings.Join(columns, " AND "), params
 }
 
+// buildWherePKs returns the condition matching the primary keys of any of the structs in the passed slice.
+func buildWherePKs(target any) (string, map[string]any) {
+	var valuesList []string
+	params := map[string]any{}
+
+	slice := reflect.ValueOf(target).Elem()
+	for i := 0; i < slice.Len(); i++ {
+		var values []string
+		for _, field := range extractPks(structValToFields(slice.Index(i))) {
+			param := addW(addIdx(field.name, i))
+			values = append(values, quote(field.name)+"="+addPlaceHolder(param))
+			params[param] = field.value
+		}
+		valuesList = append(valuesList, fmt.Sprintf("(%s)", strings.Join(values, " AND ")))
+	}
+	return strings.Join(valuesList, " OR "), params
+}
+
+// pendingCommitTimestamp is written for the columns with commit_ts option in DML.
+const pendingCommitTimestamp = "PENDING_COMMIT_TIMESTAMP()"
+
+// valueExpr returns the placeholder of the param to write the field in DML, and adds the value to params.
+// PENDING_COMMIT_TIMESTAMP() is returned for the field with commit_ts option.
+func valueExpr(f field, param string, params map[string]any) string {
+	if f.commitTs {
+		return pendingCommitTimestamp
+	}
+	params[param] = f.value
+	return addPlaceHolder(param)
+}
+
+// buildWhereVersion returns the condition to check the version of the record, and adds the value to params.
+// It returns false if the struct doesn't have version option.
+func buildWhereVersion(fields []field, params map[string]any) (string, bool) {
+	for _, field := range fields {
+		if field.version {
+			param := addW(field.name)
+			params[param] = field.value
+			return quote(field.name) + "=" + addPlaceHolder(param), true
+		}
+	}
+	return "", false
+}
+
+// incrementExpr returns the set clause to increment the version.
+func incrementExpr(f field) string {
+	return quote(f.name) + "=" + quote(f.name) + "+1"
+}
+
 func addW(str string) string {
 	return "w_" + str
 }
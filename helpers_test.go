@@ -28,3 +28,27 @@ func TestValidateType(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, "final argument must be slice of struct but got slice of string", err.Error())
 }
+
+type testCompositeKey struct {
+	C     string `spanner:"C" pk:"3"`
+	Value string `spanner:"Value"`
+	A     string `spanner:"A" pk:"1"`
+	B     int64  `spanner:"B" pk:"2,desc"`
+}
+
+var testCompositeKeyRecord = &testCompositeKey{C: "c", Value: "v", A: "a", B: 2}
+
+func TestExtractPks(t *testing.T) {
+	pks := extractPks(toFields(testCompositeKeyRecord))
+	assert.Len(t, pks, 3)
+	assert.Equal(t, "A", pks[0].name)
+	assert.Equal(t, "B", pks[1].name)
+	assert.True(t, pks[1].pkDesc)
+	assert.Equal(t, "C", pks[2].name)
+}
+
+func TestBuildWherePK(t *testing.T) {
+	where, params := buildWherePK(toFields(testCompositeKeyRecord))
+	assert.Equal(t, "`A`=@w_A AND `B`=@w_B AND `C`=@w_C", where)
+	assert.Equal(t, map[string]any{"w_A": "a", "w_B": int64(2), "w_C": "c"}, params)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -129,9 +130,9 @@ func TestMain(m *testing.M) {
 
 func initSpannerContainer(ctx context.Context) (testcontainers.Container, error) {
 	req := testcontainers.ContainerRequest{
-		Image:        "gcr.io/cloud-spanner-emulator/emulator:1.3.0",
+		Image:        "gcr.io/cloud-spanner-emulator/emulator:1.5.23",
 		ExposedPorts: []string{"9010/tcp"},
-		WaitingFor:   wait.ForLog("gateway.go:142: gRPC server listening at 0.0.0.0:9010"),
+		WaitingFor:   wait.ForLog("gRPC server listening at 0.0.0.0:9010"),
 	}
 	spannerC, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 		ContainerRequest: req,
@@ -187,10 +188,16 @@ func initDatabase(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	var statements []string
+	for _, stmt := range strings.Split(string(b), ";") {
+		if strings.TrimSpace(stmt) != "" {
+			statements = append(statements, stmt)
+		}
+	}
 	createDatabaseReq := &databasepb.CreateDatabaseRequest{
 		Parent:          instanceID,
 		CreateStatement: "CREATE DATABASE " + databaseName,
-		ExtraStatements: []string{string(b)},
+		ExtraStatements: statements,
 	}
 	cdOp, err := adminClient.CreateDatabase(ctx, createDatabaseReq)
 	if err != nil {
@@ -343,9 +343,9 @@ func TestMain(m *testing.M) {
 
 func initSpannerContainer(ctx context.Context) (testcontainers.Container, error) {
 	req := testcontainers.ContainerRequest{
-		Image:        "gcr.io/cloud-spanner-emulator/emulator:1.3.0",
+		Image:        "gcr.io/cloud-spanner-emulator/emulator:1.5.23",
 		ExposedPorts: []string{"9010/tcp"},
-		WaitingFor:   wait.ForLog("gateway.go:142: gRPC server listening at 0.0.0.0:9010"),
+		WaitingFor:   wait.ForLog("gRPC server listening at 0.0.0.0:9010"),
 	}
 	spannerC, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 		ContainerRequest: req,
@@ -5,14 +5,24 @@ package spnr
 
 import (
 	"context"
+	"time"
+
+	"cloud.google.com/go/spanner"
 )
 
 // DML offers ORM with Mutation API.
 // It also contains read operations (call Reader method.)
 type Mutation struct {
-	table      string
-	logger     logger
-	logEnabled bool
+	table       string
+	logger      logger
+	logEnabled  bool
+	strict      bool
+	chunkPolicy *ChunkPolicy
+	pageKey     []byte
+	indexes     map[string][]string
+	opts        requestOptions
+	bound       *spanner.TimestampBound
+	softDelete  string
 }
 
 // New is alias for NewMutation.
@@ -30,7 +40,7 @@ func NewMutation(tableName string) *Mutation {
 // NewDMLWithOptions initializes Mutation with options.
 // Check Options for the available options.
 func NewMutationWithOptions(tableName string, op *Options) *Mutation {
-	m := &Mutation{table: tableName, logger: op.Logger, logEnabled: op.LogEnabled}
+	m := &Mutation{table: tableName, logger: op.Logger, logEnabled: op.LogEnabled, strict: op.StrictValidation, chunkPolicy: op.ChunkPolicy, pageKey: op.PageTokenKey, indexes: op.Indexes, opts: newRequestOptions(op.RequestOptions), bound: op.TimestampBound, softDelete: op.SoftDeleteColumn}
 	if m.logger == nil {
 		m.logger = newDefaultLogger()
 	}
@@ -39,7 +49,26 @@ func NewMutationWithOptions(tableName string, op *Options) *Mutation {
 
 // Reader returns Reader struct to call read operations.
 func (m *Mutation) Reader(ctx context.Context, tx Transaction) *Reader {
-	return &Reader{table: m.table, ctx: ctx, tx: tx, logger: m.logger, logEnabled: m.logEnabled}
+	return &Reader{table: m.table, ctx: ctx, tx: tx, logger: m.logger, logEnabled: m.logEnabled, strict: m.strict, pageKey: m.pageKey, indexes: m.indexes, opts: m.opts, softDelete: m.softDelete}
+}
+
+// StaleReader returns Reader which executes each read in a new single-use read-only transaction with the timestamp bound.
+// See StaleTransaction for the details.
+func (m *Mutation) StaleReader(ctx context.Context, client *spanner.Client, bound spanner.TimestampBound) *Reader {
+	return m.Reader(ctx, NewStaleTransaction(client, bound))
+}
+
+// SingleReader is basically same as StaleReader, but it reads with Options.TimestampBound (strong read if it's not specified).
+func (m *Mutation) SingleReader(ctx context.Context, client *spanner.Client) *Reader {
+	return m.StaleReader(ctx, client, defaultBound(m.bound))
+}
+
+// With returns the copy of Mutation which sends the requests with the passed options.
+// The options are added to the ones specified in Options.RequestOptions.
+func (m *Mutation) With(opts ...Option) *Mutation {
+	c := *m
+	c.opts = m.opts.with(opts...)
+	return &c
 }
 
 // GetTableName returns table name
@@ -52,3 +81,22 @@ func (m *Mutation) logf(format string, v ...any) {
 		m.logger.Printf(format, v...)
 	}
 }
+
+func (m *Mutation) apply(ctx context.Context, client *spanner.Client, ms []*spanner.Mutation) (time.Time, error) {
+	return client.Apply(ctx, ms, m.opts.applyOptions()...)
+}
+
+func (m *Mutation) getChunkPolicy() ChunkPolicy {
+	if m.chunkPolicy == nil {
+		return DefaultChunkPolicy
+	}
+	return *m.chunkPolicy
+}
+
+func (m *Mutation) validate(target any) (isStruct bool, err error) {
+	isStruct, err = validateStructOrStructSliceType(target)
+	if err != nil {
+		return false, err
+	}
+	return isStruct, validateTags(target, m.strict)
+}
@@ -0,0 +1,96 @@
+package spnr
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+)
+
+// ApplyInsertChunked is basically same as ApplyInsert, but it splits the records into chunks following the ChunkPolicy in Options,
+// and applies each chunk as a separate transaction.
+// It returns the result of each applied chunk. The returned error is the first error of the chunks.
+// Note that the chunks applied before the failure are not rolled back.
+// There are no chunked methods taking a transaction, because the mutations buffered in one transaction are committed together
+// and count toward the same commit limit whether they are split or not. Use DML.InsertChunked to split the writes in a transaction.
+func (m *Mutation) ApplyInsertChunked(ctx context.Context, client *spanner.Client, target any) ([]ChunkResult, error) {
+	return m.applyChunked(ctx, client, target, m.buildInsert, false, toAlreadyExists, false)
+}
+
+// ApplyReplaceChunked is basically same as ApplyReplace, but it splits the records into chunks.
+// See ApplyInsertChunked for the details.
+func (m *Mutation) ApplyReplaceChunked(ctx context.Context, client *spanner.Client, target any) ([]ChunkResult, error) {
+	return m.applyChunked(ctx, client, target, m.buildReplace, false, withStack, false)
+}
+
+// ApplyInsertOrUpdateChunked is basically same as ApplyInsertOrUpdate, but it splits the records into chunks.
+// See ApplyInsertChunked for the details.
+func (m *Mutation) ApplyInsertOrUpdateChunked(ctx context.Context, client *spanner.Client, target any) ([]ChunkResult, error) {
+	return m.applyChunked(ctx, client, target, m.buildInsertOrUpdate, false, withStack, false)
+}
+
+// ApplyUpdateChunked is basically same as ApplyUpdate, but it splits the records into chunks.
+// See ApplyInsertChunked for the details.
+func (m *Mutation) ApplyUpdateChunked(ctx context.Context, client *spanner.Client, target any) ([]ChunkResult, error) {
+	return m.applyChunked(ctx, client, target, m.buildUpdate, false, withStack, true)
+}
+
+// ApplyDeleteChunked is basically same as ApplyDelete, but it splits the records into chunks.
+// See ApplyInsertChunked for the details.
+func (m *Mutation) ApplyDeleteChunked(ctx context.Context, client *spanner.Client, target any) ([]ChunkResult, error) {
+	return m.applyChunked(ctx, client, target, m.buildDelete, true, withStack, false)
+}
+
+// applyChunked applies the mutations built by build for each chunk.
+// If isUpdate is true, the versions of the records are checked for each chunk (see Mutation.Update.)
+func (m *Mutation) applyChunked(ctx context.Context, client *spanner.Client, target any, build func([]any) []*spanner.Mutation, isDelete bool, wrapErr func(error) error, isUpdate bool) ([]ChunkResult, error) {
+	isStruct, err := m.validate(target)
+	if err != nil {
+		return nil, err
+	}
+	targets := []any{target}
+	if !isStruct {
+		targets = toStructSlice(target)
+	}
+
+	policy := m.getChunkPolicy()
+	var results []ChunkResult
+	for _, c := range m.splitChunks(targets, isDelete, policy) {
+		var t time.Time
+		switch {
+		case isUpdate:
+			t, err = m.applyUpdate(ctx, client, targets[c.offset:c.offset+c.len], build)
+		case isDelete && m.softDelete != "":
+			t, err = m.applySoftDelete(ctx, client, targets[c.offset:c.offset+c.len], true)
+		default:
+			t, err = m.apply(ctx, client, build(targets[c.offset:c.offset+c.len]))
+		}
+		results = append(results, ChunkResult{Offset: c.offset, Len: c.len, CommitTimestamp: t, Err: wrapErr(err)})
+		if err != nil && !policy.ContinueOnError {
+			break
+		}
+	}
+	return results, firstChunkErr(results)
+}
+
+func (m *Mutation) splitChunks(targets []any, isDelete bool, policy ChunkPolicy) []chunk {
+	return splitChunks(len(targets), func(i int) chunkCost {
+		if isDelete {
+			key := toKey(reflect.ValueOf(targets[i]))
+			if m.softDelete != "" {
+				// soft delete writes the primary key columns and the soft delete column
+				return chunkCost{cells: len(key) + 1, bytes: estimateValuesSize(key)}
+			}
+			// deleting a row is counted as one mutation regardless of the columns
+			return chunkCost{cells: 1, bytes: estimateValuesSize(key)}
+		}
+		values := toValues(targets[i])
+		return chunkCost{cells: len(values), bytes: estimateValuesSize(values)}
+	}, policy)
+}
+
+func withStack(err error) error {
+	return errors.WithStack(err)
+}
@@ -2,6 +2,7 @@ package spnr
 
 import (
 	"context"
+	"reflect"
 	"time"
 
 	"cloud.google.com/go/spanner"
@@ -12,39 +13,52 @@ import (
 // You can pass either a struct or a slice of structs.
 // If you pass a slice of structs, this method will build a mutation for each struct.
 // This method requires spanner.ReadWriteTransaction, and will call spanner.ReadWriteTransaction.BufferWrite to save the mutation to transaction.
+// If Options.SoftDeleteColumn is specified, the column is set to the commit timestamp instead of deleting the records.
+// In that case the soft delete column is read in the transaction first, and the records which don't exist or are already soft deleted are skipped like DML.Delete.
+// Use HardDelete to delete the records physically.
 func (m *Mutation) Delete(tx *spanner.ReadWriteTransaction, target any) error {
-	isStruct, err := validateStructOrStructSliceType(target)
+	return m.DeleteWithContext(context.Background(), tx, target)
+}
+
+// DeleteWithContext is basically same as Delete, but the soft delete column is read with the passed context.
+func (m *Mutation) DeleteWithContext(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) error {
+	isStruct, err := m.validate(target)
 	if err != nil {
 		return err
 	}
-	if isStruct {
-		return errors.WithStack(tx.BufferWrite(m.buildDelete([]any{target})))
+	targets := []any{target}
+	if !isStruct {
+		targets = toStructSlice(target)
 	}
-	return errors.WithStack(tx.BufferWrite(m.buildDelete(toStructSlice(target))))
+	if m.softDelete != "" {
+		return m.bufferSoftDelete(ctx, tx, targets, true)
+	}
+	return errors.WithStack(tx.BufferWrite(m.buildDelete(targets)))
 }
 
 // ApplyDelete is basically same as Delete, but it doesn't require transaction.
 // This method directly calls mutation API without transaction by calling spanner.Client.Apply method.
+// If Options.SoftDeleteColumn is specified, it runs a read-write transaction to read the soft delete column.
 func (m *Mutation) ApplyDelete(ctx context.Context, client *spanner.Client, target any) (time.Time, error) {
-	isStruct, err := validateStructOrStructSliceType(target)
+	isStruct, err := m.validate(target)
 	if err != nil {
 		return time.Time{}, err
 	}
-	if isStruct {
-		t, err := client.Apply(ctx, m.buildDelete([]any{target}))
-		return t, errors.WithStack(err)
+	targets := []any{target}
+	if !isStruct {
+		targets = toStructSlice(target)
+	}
+	if m.softDelete != "" {
+		return m.applySoftDelete(ctx, client, targets, true)
 	}
-	t, err := client.Apply(ctx, m.buildDelete(toStructSlice(target)))
+	t, err := m.apply(ctx, client, m.buildDelete(targets))
 	return t, errors.WithStack(err)
 }
 
 func (m *Mutation) buildDelete(targets []any) []*spanner.Mutation {
 	var ms []*spanner.Mutation
 	for _, target := range targets {
-		var pks spanner.Key
-		for _, pk := range extractPks(toFields(target)) {
-			pks = append(pks, pk.value)
-		}
+		pks := toKey(reflect.ValueOf(target))
 		ms = append(ms, spanner.Delete(m.table, pks))
 		m.logf("Deleting from %s, key=%+v", m.table, pks)
 	}
@@ -45,3 +45,9 @@ func TestMutation_DeleteWithSlicePointer(t *testing.T) {
 	_ = testRepository.Reader(ctx, dataClient.Single()).FindAll(keySet, &fetched)
 	assert.Empty(t, fetched)
 }
+
+func TestMutation_buildDeleteCompositeKey(t *testing.T) {
+	ms := New("Composite").buildDelete([]any{testCompositeKeyRecord})
+	assert.Len(t, ms, 1)
+	assert.Equal(t, spanner.Delete("Composite", spanner.Key{"a", int64(2), "c"}), ms[0])
+}
@@ -0,0 +1,141 @@
+package spnr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+)
+
+// ErrAlreadyExists is returned when an insert operation finds the record already exists.
+var ErrAlreadyExists = errors.New("record already exists")
+
+// Insert build and execute insert operation using mutation API.
+// Unlike InsertOrUpdate, the operation fails if the record already exists.
+// You can pass either a struct or a slice of structs.
+// If you pass a slice of structs, this method will call multiple mutations for each struct.
+// This method requires spanner.ReadWriteTransaction, and will call spanner.ReadWriteTransaction.BufferWrite to save the mutation to transaction.
+// Since mutations are applied on commit, the error is returned by spanner.Client.ReadWriteTransaction. Use IsAlreadyExists to check it.
+// If you want to insert only the specified columns, use InsertColumns instead.
+func (m *Mutation) Insert(tx *spanner.ReadWriteTransaction, target any) error {
+	isStruct, err := m.validate(target)
+	if err != nil {
+		return err
+	}
+	if isStruct {
+		return errors.WithStack(tx.BufferWrite(m.buildInsert([]any{target})))
+	}
+	return errors.WithStack(tx.BufferWrite(m.buildInsert(toStructSlice(target))))
+}
+
+// ApplyInsert is basically same as Insert, but it doesn't require transaction.
+// This method directly calls mutation API without transaction by calling spanner.Client.Apply method.
+// If the record already exists, this method will return the error which matches ErrAlreadyExists by errors.Is.
+// If you want to insert only the specified columns, use ApplyInsertColumns instead.
+func (m *Mutation) ApplyInsert(ctx context.Context, client *spanner.Client, target any) (time.Time, error) {
+	isStruct, err := m.validate(target)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if isStruct {
+		t, err := m.apply(ctx, client, m.buildInsert([]any{target}))
+		return t, toAlreadyExists(err)
+	}
+	t, err := m.apply(ctx, client, m.buildInsert(toStructSlice(target)))
+	return t, toAlreadyExists(err)
+}
+
+// InsertColumns build and execute insert operation for specified columns using mutation API.
+// You can pass either a struct or a slice of structs to target.
+// If you pass a slice of structs, this method will build a mutation for each struct.
+// This method requires spanner.ReadWriteTransaction, and will call spanner.ReadWriteTransaction.BufferWrite to save the mutation to transaction.
+func (m *Mutation) InsertColumns(tx *spanner.ReadWriteTransaction, columns []string, target any) error {
+	isStruct, err := m.validate(target)
+	if err != nil {
+		return err
+	}
+	if isStruct {
+		return errors.WithStack(tx.BufferWrite(m.buildInsertWithColumns(columns, []any{target})))
+	}
+	return errors.WithStack(tx.BufferWrite(m.buildInsertWithColumns(columns, toStructSlice(target))))
+}
+
+// ApplyInsertColumns is basically same as InsertColumns, but it doesn't require transaction.
+// This method directly calls mutation API without transaction by calling spanner.Client.Apply method.
+// If the record already exists, this method will return the error which matches ErrAlreadyExists by errors.Is.
+func (m *Mutation) ApplyInsertColumns(ctx context.Context, client *spanner.Client, columns []string, target any) (time.Time, error) {
+	isStruct, err := m.validate(target)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if isStruct {
+		t, err := m.apply(ctx, client, m.buildInsertWithColumns(columns, []any{target}))
+		return t, toAlreadyExists(err)
+	}
+	t, err := m.apply(ctx, client, m.buildInsertWithColumns(columns, toStructSlice(target)))
+	return t, toAlreadyExists(err)
+}
+
+// IsAlreadyExists reports whether the error is caused by inserting the record which already exists.
+// It is useful to check the error returned by spanner.Client.ReadWriteTransaction after calling Insert.
+func IsAlreadyExists(err error) bool {
+	if errors.Is(err, ErrAlreadyExists) {
+		return true
+	}
+	var se *spanner.Error
+	return errors.As(err, &se) && se.Code == codes.AlreadyExists
+}
+
+// alreadyExistsError is ErrAlreadyExists which keeps the error returned by the spanner client.
+type alreadyExistsError struct {
+	err error
+}
+
+func (e *alreadyExistsError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrAlreadyExists, e.err)
+}
+
+// Is reports whether target is ErrAlreadyExists.
+func (e *alreadyExistsError) Is(target error) bool {
+	return target == ErrAlreadyExists
+}
+
+// Unwrap returns the error returned by the spanner client.
+func (e *alreadyExistsError) Unwrap() error {
+	return e.err
+}
+
+func toAlreadyExists(err error) error {
+	if err == nil {
+		return nil
+	}
+	if IsAlreadyExists(err) {
+		return errors.WithStack(&alreadyExistsError{err: err})
+	}
+	return errors.WithStack(err)
+}
+
+func (m *Mutation) buildInsert(targets []any) []*spanner.Mutation {
+	var ms []*spanner.Mutation
+	for _, target := range targets {
+		columns := getStructInfo(reflect.TypeOf(target).Elem()).columns
+		values := toValues(target)
+		m.logf("Insert into %s, columns=%+v, values=%+v", m.table, columns, values)
+		ms = append(ms, spanner.Insert(m.table, columns, values))
+	}
+	return ms
+}
+
+func (m *Mutation) buildInsertWithColumns(columns []string, targets []any) []*spanner.Mutation {
+	var ms []*spanner.Mutation
+	for _, target := range targets {
+		values := toColumnValues(target, columns)
+		m.logf("Insert into %s, columns=%+v, values=%+v", m.table, columns, values)
+		ms = append(ms, spanner.Insert(m.table, columns, values))
+	}
+	return ms
+}
@@ -0,0 +1,78 @@
+package spnr
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMutation_Insert(t *testing.T) {
+	ctx := context.Background()
+	_, err := testRepository.ApplyInsert(ctx, dataClient, testRecord3)
+	assert.Nil(t, err)
+	var fetched Test
+	err = testRepository.Reader(ctx, dataClient.Single()).FindOne(spanner.Key{testRecord3.String, testRecord3.Int64}, &fetched)
+	assert.Nil(t, err)
+	assert.Equal(t, testRecord3.Bytes, fetched.Bytes)
+	assert.Equal(t, testRecord3.NullString, fetched.NullString)
+
+	_, err = testRepository.ApplyInsert(ctx, dataClient, testRecord3)
+	assert.ErrorIs(t, err, ErrAlreadyExists)
+
+	_, err = dataClient.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		return testRepository.Insert(tx, &([]*Test{testRecord3}))
+	})
+	assert.True(t, IsAlreadyExists(err))
+
+	// clean up
+	_, err = testRepository.ApplyDelete(ctx, dataClient, testRecord3)
+	assert.Nil(t, err)
+}
+
+func TestMutation_Replace(t *testing.T) {
+	ctx := context.Background()
+	_, err := testRepository.ApplyInsert(ctx, dataClient, testRecord3)
+	assert.Nil(t, err)
+
+	replaced := *testRecord3
+	replaced.NullString = NewNullString("replaced")
+	_, err = testRepository.ApplyReplaceColumns(ctx, dataClient, []string{"String", "Bytes", "Int64", "Float64", "Numeric", "Bool", "Date", "Timestamp", "NullString"}, &replaced)
+	assert.Nil(t, err)
+
+	var fetched Test
+	err = testRepository.Reader(ctx, dataClient.Single()).FindOne(spanner.Key{testRecord3.String, testRecord3.Int64}, &fetched)
+	assert.Nil(t, err)
+	assert.Equal(t, replaced.NullString, fetched.NullString)
+	assert.False(t, fetched.NullInt64.Valid)
+	assert.Nil(t, fetched.ArrayInt64)
+
+	// clean up
+	_, err = testRepository.ApplyDelete(ctx, dataClient, testRecord3)
+	assert.Nil(t, err)
+}
+
+func TestIsAlreadyExists(t *testing.T) {
+	assert.True(t, IsAlreadyExists(ErrAlreadyExists))
+	assert.True(t, IsAlreadyExists(errors.WithStack(spanner.ToSpannerError(status.Error(codes.AlreadyExists, "row exists")))))
+	assert.False(t, IsAlreadyExists(spanner.ToSpannerError(status.Error(codes.NotFound, "not found"))))
+	assert.False(t, IsAlreadyExists(nil))
+}
+
+func TestToAlreadyExists(t *testing.T) {
+	cause := spanner.ToSpannerError(status.Error(codes.AlreadyExists, "row exists"))
+	err := toAlreadyExists(cause)
+	assert.ErrorIs(t, err, ErrAlreadyExists)
+	var se *spanner.Error
+	assert.ErrorAs(t, err, &se)
+	assert.Same(t, cause, se)
+	assert.Contains(t, err.Error(), "row exists")
+
+	notFound := spanner.ToSpannerError(status.Error(codes.NotFound, "not found"))
+	assert.NotErrorIs(t, toAlreadyExists(notFound), ErrAlreadyExists)
+	assert.Nil(t, toAlreadyExists(nil))
+}
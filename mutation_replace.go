@@ -0,0 +1,94 @@
+package spnr
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+)
+
+// Replace build and execute replace operation using mutation API.
+// If the record already exists, it is deleted and inserted again, so the columns not included in the struct will be NULL.
+// You can pass either a struct or a slice of structs.
+// If you pass a slice of structs, this method will call multiple mutations for each struct.
+// This method requires spanner.ReadWriteTransaction, and will call spanner.ReadWriteTransaction.BufferWrite to save the mutation to transaction.
+// If you want to replace only the specified columns, use ReplaceColumns instead.
+func (m *Mutation) Replace(tx *spanner.ReadWriteTransaction, target any) error {
+	isStruct, err := m.validate(target)
+	if err != nil {
+		return err
+	}
+	if isStruct {
+		return errors.WithStack(tx.BufferWrite(m.buildReplace([]any{target})))
+	}
+	return errors.WithStack(tx.BufferWrite(m.buildReplace(toStructSlice(target))))
+}
+
+// ApplyReplace is basically same as Replace, but it doesn't require transaction.
+// This method directly calls mutation API without transaction by calling spanner.Client.Apply method.
+// If you want to replace only the specified columns, use ApplyReplaceColumns instead.
+func (m *Mutation) ApplyReplace(ctx context.Context, client *spanner.Client, target any) (time.Time, error) {
+	isStruct, err := m.validate(target)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if isStruct {
+		t, err := m.apply(ctx, client, m.buildReplace([]any{target}))
+		return t, errors.WithStack(err)
+	}
+	t, err := m.apply(ctx, client, m.buildReplace(toStructSlice(target)))
+	return t, errors.WithStack(err)
+}
+
+// ReplaceColumns build and execute replace operation for specified columns using mutation API.
+// You can pass either a struct or a slice of structs to target.
+// If you pass a slice of structs, this method will build a mutation for each struct.
+// This method requires spanner.ReadWriteTransaction, and will call spanner.ReadWriteTransaction.BufferWrite to save the mutation to transaction.
+func (m *Mutation) ReplaceColumns(tx *spanner.ReadWriteTransaction, columns []string, target any) error {
+	isStruct, err := m.validate(target)
+	if err != nil {
+		return err
+	}
+	if isStruct {
+		return errors.WithStack(tx.BufferWrite(m.buildReplaceWithColumns(columns, []any{target})))
+	}
+	return errors.WithStack(tx.BufferWrite(m.buildReplaceWithColumns(columns, toStructSlice(target))))
+}
+
+// ApplyReplaceColumns is basically same as ReplaceColumns, but it doesn't require transaction.
+// This method directly calls mutation API without transaction by calling spanner.Client.Apply method.
+func (m *Mutation) ApplyReplaceColumns(ctx context.Context, client *spanner.Client, columns []string, target any) (time.Time, error) {
+	isStruct, err := m.validate(target)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if isStruct {
+		t, err := m.apply(ctx, client, m.buildReplaceWithColumns(columns, []any{target}))
+		return t, errors.WithStack(err)
+	}
+	t, err := m.apply(ctx, client, m.buildReplaceWithColumns(columns, toStructSlice(target)))
+	return t, errors.WithStack(err)
+}
+
+func (m *Mutation) buildReplace(targets []any) []*spanner.Mutation {
+	var ms []*spanner.Mutation
+	for _, target := range targets {
+		columns := getStructInfo(reflect.TypeOf(target).Elem()).columns
+		values := toValues(target)
+		m.logf("Replace %s, columns=%+v, values=%+v", m.table, columns, values)
+		ms = append(ms, spanner.Replace(m.table, columns, values))
+	}
+	return ms
+}
+
+func (m *Mutation) buildReplaceWithColumns(columns []string, targets []any) []*spanner.Mutation {
+	var ms []*spanner.Mutation
+	for _, target := range targets {
+		values := toColumnValues(target, columns)
+		m.logf("Replace %s, columns=%+v, values=%+v", m.table, columns, values)
+		ms = append(ms, spanner.Replace(m.table, columns, values))
+	}
+	return ms
+}
@@ -2,7 +2,7 @@ package spnr
 
 import (
 	"context"
-	"strings"
+	"reflect"
 	"time"
 
 	"cloud.google.com/go/spanner"
@@ -14,30 +14,43 @@ import (
 // If you pass a slice of structs, this method will call multiple mutations for each struct.
 // This method requires spanner.ReadWriteTransaction, and will call spanner.ReadWriteTransaction.BufferWrite to save the mutation to transaction.
 // If you want to update only the specified columns, use UpdateColumns instead.
+// If the struct has version option (e.g. `spanner:"Version,version"`), the current versions are read in the transaction before writing,
+// and ErrStaleVersion is returned if they don't match. The version is incremented by the mutation, but the passed struct is not changed.
+// The versions are read with context.Background(), so use UpdateWithContext to read them with your context.
 func (m *Mutation) Update(tx *spanner.ReadWriteTransaction, target any) error {
-	isStruct, err := validateStructOrStructSliceType(target)
+	return m.UpdateWithContext(context.Background(), tx, target)
+}
+
+// UpdateWithContext is basically same as Update, but the versions are read with the passed context.
+func (m *Mutation) UpdateWithContext(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) error {
+	isStruct, err := m.validate(target)
 	if err != nil {
 		return err
 	}
-	if isStruct {
-		return errors.WithStack(tx.BufferWrite(m.buildUpdate([]any{target})))
+	targets := []any{target}
+	if !isStruct {
+		targets = toStructSlice(target)
 	}
-	return errors.WithStack(tx.BufferWrite(m.buildUpdate(toStructSlice(target))))
+	if err := m.checkVersions(ctx, tx, targets); err != nil {
+		return err
+	}
+	return errors.WithStack(tx.BufferWrite(m.buildUpdate(targets)))
 }
 
 // ApplyUpdate is basically same as Update, but it doesn't require transaction.
 // This method directly calls mutation API without transaction by calling spanner.Client.Apply method.
 // If you want to update only the specified columns, use ApplyUpdateColumns instead.
+// If the struct has version option, the versions are checked in a read-write transaction instead (see Update.)
 func (m *Mutation) ApplyUpdate(ctx context.Context, client *spanner.Client, target any) (time.Time, error) {
-	isStruct, err := validateStructOrStructSliceType(target)
+	isStruct, err := m.validate(target)
 	if err != nil {
 		return time.Time{}, err
 	}
 	if isStruct {
-		t, err := client.Apply(ctx, m.buildUpdate([]any{target}))
+		t, err := m.applyUpdate(ctx, client, []any{target}, m.buildUpdate)
 		return t, errors.WithStack(err)
 	}
-	t, err := client.Apply(ctx, m.buildUpdate(toStructSlice(target)))
+	t, err := m.applyUpdate(ctx, client, toStructSlice(target), m.buildUpdate)
 	return t, errors.WithStack(err)
 }
 
@@ -45,41 +58,49 @@ func (m *Mutation) ApplyUpdate(ctx context.Context, client *spanner.Client, targ
 // You can pass either a struct or a slice of structs to target.
 // If you pass a slice of structs, this method will build a mutation for each struct.
 // This method requires spanner.ReadWriteTransaction, and will call spanner.ReadWriteTransaction.BufferWrite to save the mutation to transaction.
+// The version is checked and incremented in the same way as Update even if it's not in the columns.
 func (m *Mutation) UpdateColumns(tx *spanner.ReadWriteTransaction, columns []string, target any) error {
-	isStruct, err := validateStructOrStructSliceType(target)
+	return m.UpdateColumnsWithContext(context.Background(), tx, columns, target)
+}
+
+// UpdateColumnsWithContext is basically same as UpdateColumns, but the versions are read with the passed context.
+func (m *Mutation) UpdateColumnsWithContext(ctx context.Context, tx *spanner.ReadWriteTransaction, columns []string, target any) error {
+	isStruct, err := m.validate(target)
 	if err != nil {
 		return err
 	}
-	if isStruct {
-		return errors.WithStack(tx.BufferWrite(m.buildUpdateWithColumns([]any{target}, columns)))
+	targets := []any{target}
+	if !isStruct {
+		targets = toStructSlice(target)
+	}
+	if err := m.checkVersions(ctx, tx, targets); err != nil {
+		return err
 	}
-	return errors.WithStack(tx.BufferWrite(m.buildUpdateWithColumns(toStructSlice(target), columns)))
+	return errors.WithStack(tx.BufferWrite(m.buildUpdateWithColumns(targets, columns)))
 }
 
 // ApplyUpdateColumns is basically same as UpdateColumns, but it doesn't require transaction.
 // This method directly calls mutation API without transaction by calling spanner.Client.Apply method.
 func (m *Mutation) ApplyUpdateColumns(ctx context.Context, client *spanner.Client, columns []string, target any) (time.Time, error) {
-	isStruct, err := validateStructOrStructSliceType(target)
+	isStruct, err := m.validate(target)
 	if err != nil {
 		return time.Time{}, err
 	}
+	build := func(targets []any) []*spanner.Mutation {
+		return m.buildUpdateWithColumns(targets, columns)
+	}
 	if isStruct {
-		t, err := client.Apply(ctx, m.buildUpdateWithColumns([]any{target}, columns))
+		t, err := m.applyUpdate(ctx, client, []any{target}, build)
 		return t, errors.WithStack(err)
 	}
-	t, err := client.Apply(ctx, m.buildUpdateWithColumns(toStructSlice(target), columns))
+	t, err := m.applyUpdate(ctx, client, toStructSlice(target), build)
 	return t, errors.WithStack(err)
 }
 
 func (m *Mutation) buildUpdate(targets []any) []*spanner.Mutation {
 	var ms []*spanner.Mutation
 	for _, target := range targets {
-		var columns []string
-		var values []any
-		for _, field := range toFields(target) {
-			columns = append(columns, field.name)
-			values = append(values, field.value)
-		}
+		columns, values := incrementVersion(target, getStructInfo(reflect.TypeOf(target).Elem()).columns, toValues(target))
 		m.logf("Update %s, columns=%+v, values=%+v", m.table, columns, values)
 		ms = append(ms, spanner.Update(m.table, columns, values))
 	}
@@ -89,16 +110,9 @@ func (m *Mutation) buildUpdate(targets []any) []*spanner.Mutation {
 func (m *Mutation) buildUpdateWithColumns(targets []any, columns []string) []*spanner.Mutation {
 	var ms []*spanner.Mutation
 	for _, target := range targets {
-		fieldNameToField := map[string]field{}
-		for _, f := range toFields(target) {
-			fieldNameToField[strings.ToLower(f.name)] = f
-		}
-		var values []any
-		for _, c := range columns {
-			values = append(values, fieldNameToField[strings.ToLower(c)].value)
-		}
-		m.logf("Update %s, columns=%+v, values=%+v", m.table, columns, values)
-		ms = append(ms, spanner.Update(m.table, columns, values))
+		cols, values := incrementVersion(target, columns, toColumnValues(target, columns))
+		m.logf("Update %s, columns=%+v, values=%+v", m.table, cols, values)
+		ms = append(ms, spanner.Update(m.table, cols, values))
 	}
 	return ms
 }
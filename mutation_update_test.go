@@ -3,6 +3,7 @@ package spnr
 import (
 	"context"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/spanner"
 	"github.com/stretchr/testify/assert"
@@ -129,3 +130,46 @@ func TestMutation_UpdateWithSlicePointer(t *testing.T) {
 	_, err = testRepository.ApplyDelete(ctx, dataClient, &([]*Test{testRecord3, testRecord4}))
 	assert.Nil(t, err)
 }
+
+func TestMutation_UpdatePointers(t *testing.T) {
+	ctx := context.Background()
+	_, err := testRepository.ApplyInsertOrUpdate(ctx, dataClient, testRecord3)
+	assert.Nil(t, err)
+
+	s := "pointer"
+	now := time.Now().UTC()
+	store := NewMutationStore[testPointers]("Test")
+	_, err = store.ApplyUpdate(ctx, dataClient, &testPointers{String: testRecord3.String, Int64: testRecord3.Int64, NullString: &s, NullTimestamp: &now})
+	assert.Nil(t, err)
+
+	fetched, err := store.FindOne(ctx, dataClient.Single(), spanner.Key{testRecord3.String, testRecord3.Int64})
+	assert.Nil(t, err)
+	assert.Equal(t, s, *fetched.NullString)
+	assert.True(t, now.Equal(*fetched.NullTimestamp))
+	assert.Nil(t, fetched.NullInt64)
+	assert.Nil(t, fetched.NullDate)
+
+	// clean up
+	_, err = testRepository.ApplyDelete(ctx, dataClient, testRecord3)
+	assert.Nil(t, err)
+}
+
+func TestMutation_buildUpdateVersion(t *testing.T) {
+	m := NewMutation("Versions")
+	columns, values := incrementVersion(&testVersion{ID: "a", Name: "b", Version: 3}, []string{"ID", "Name", "Version"}, []any{"a", "b", int64(3)})
+	assert.Equal(t, []string{"ID", "Name", "Version"}, columns)
+	assert.Equal(t, []any{"a", "b", int64(4)}, values)
+
+	columns = []string{"ID", "Name"}
+	cols, values := incrementVersion(&testVersion{ID: "a", Name: "b", Version: 3}, columns, []any{"a", "b"})
+	assert.Equal(t, []string{"ID", "Name", "Version"}, cols)
+	assert.Equal(t, []any{"a", "b", int64(4)}, values)
+	assert.Equal(t, []string{"ID", "Name"}, columns)
+
+	assert.Len(t, m.buildUpdateWithColumns([]any{&testVersion{ID: "a"}}, columns), 1)
+
+	// the version column is matched case-insensitively like the other columns
+	cols, values = incrementVersion(&testVersion{ID: "a", Name: "b", Version: 3}, []string{"ID", "version"}, []any{"a", int64(3)})
+	assert.Equal(t, []string{"ID", "version"}, cols)
+	assert.Equal(t, []any{"a", int64(4)}, values)
+}
@@ -2,7 +2,7 @@ package spnr
 
 import (
 	"context"
-	"strings"
+	"reflect"
 	"time"
 
 	"cloud.google.com/go/spanner"
@@ -15,7 +15,7 @@ import (
 // This method requires spanner.ReadWriteTransaction, and will call spanner.ReadWriteTransaction.BufferWrite to save the mutation to transaction.
 // If you want to insert or update only the specified columns, use InsertOrUpdateColumns instead.
 func (m *Mutation) InsertOrUpdate(tx *spanner.ReadWriteTransaction, target any) error {
-	isStruct, err := validateStructOrStructSliceType(target)
+	isStruct, err := m.validate(target)
 	if err != nil {
 		return err
 	}
@@ -29,15 +29,15 @@ func (m *Mutation) InsertOrUpdate(tx *spanner.ReadWriteTransaction, target any)
 // This method directly calls mutation API without transaction by calling spanner.Client.Apply method.
 // If you want to insert or update only the specified columns, use ApplyInsertOrUpdateColumns instead.
 func (m *Mutation) ApplyInsertOrUpdate(ctx context.Context, client *spanner.Client, target any) (time.Time, error) {
-	isStruct, err := validateStructOrStructSliceType(target)
+	isStruct, err := m.validate(target)
 	if err != nil {
 		return time.Time{}, err
 	}
 	if isStruct {
-		t, err := client.Apply(ctx, m.buildInsertOrUpdate([]any{target}))
+		t, err := m.apply(ctx, client, m.buildInsertOrUpdate([]any{target}))
 		return t, errors.WithStack(err)
 	}
-	t, err := client.Apply(ctx, m.buildInsertOrUpdate(toStructSlice(target)))
+	t, err := m.apply(ctx, client, m.buildInsertOrUpdate(toStructSlice(target)))
 	return t, errors.WithStack(err)
 }
 
@@ -46,7 +46,7 @@ func (m *Mutation) ApplyInsertOrUpdate(ctx context.Context, client *spanner.Clie
 // If you pass a slice of structs, this method will build a mutation for each struct.
 // This method requires spanner.ReadWriteTransaction, and will call spanner.ReadWriteTransaction.BufferWrite to save the mutation to transaction.
 func (m *Mutation) InsertOrUpdateColumns(tx *spanner.ReadWriteTransaction, columns []string, target any) error {
-	isStruct, err := validateStructOrStructSliceType(target)
+	isStruct, err := m.validate(target)
 	if err != nil {
 		return err
 	}
@@ -59,27 +59,23 @@ func (m *Mutation) InsertOrUpdateColumns(tx *spanner.ReadWriteTransaction, colum
 // ApplyInsertOrUpdateColumns is basically same as InsertOrUpdateColumns, but it doesn't require transaction.
 // This method directly calls mutation API without transaction by calling spanner.Client.Apply method.
 func (m *Mutation) ApplyInsertOrUpdateColumns(ctx context.Context, client *spanner.Client, columns []string, target any) (time.Time, error) {
-	isStruct, err := validateStructOrStructSliceType(target)
+	isStruct, err := m.validate(target)
 	if err != nil {
 		return time.Time{}, err
 	}
 	if isStruct {
-		t, err := client.Apply(ctx, m.buildInsertOrUpdateWithColumns(columns, []any{target}))
+		t, err := m.apply(ctx, client, m.buildInsertOrUpdateWithColumns(columns, []any{target}))
 		return t, errors.WithStack(err)
 	}
-	t, err := client.Apply(ctx, m.buildInsertOrUpdateWithColumns(columns, toStructSlice(target)))
+	t, err := m.apply(ctx, client, m.buildInsertOrUpdateWithColumns(columns, toStructSlice(target)))
 	return t, errors.WithStack(err)
 }
 
 func (m *Mutation) buildInsertOrUpdate(targets []any) []*spanner.Mutation {
 	var ms []*spanner.Mutation
 	for _, target := range targets {
-		var columns []string
-		var values []any
-		for _, field := range toFields(target) {
-			columns = append(columns, field.name)
-			values = append(values, field.value)
-		}
+		columns := getStructInfo(reflect.TypeOf(target).Elem()).columns
+		values := toValues(target)
 		m.logf("InsertOrUpdate into %s, columns=%+v, values=%+v", m.table, columns, values)
 		ms = append(ms, spanner.InsertOrUpdate(m.table, columns, values))
 	}
@@ -89,14 +85,7 @@ func (m *Mutation) buildInsertOrUpdate(targets []any) []*spanner.Mutation {
 func (m *Mutation) buildInsertOrUpdateWithColumns(columns []string, targets []any) []*spanner.Mutation {
 	var ms []*spanner.Mutation
 	for _, target := range targets {
-		fieldNameField := map[string]field{}
-		for _, f := range toFields(target) {
-			fieldNameField[strings.ToLower(f.name)] = f
-		}
-		var values []any
-		for _, c := range columns {
-			values = append(values, fieldNameField[c])
-		}
+		values := toColumnValues(target, columns)
 		m.logf("Update %s, columns=%+v, values=%+v", m.table, columns, values)
 		ms = append(ms, spanner.InsertOrUpdate(m.table, columns, values))
 	}
@@ -120,3 +120,9 @@ func TestMutation_InsertOrUpdateWithSlice(t *testing.T) {
 	_, err = testRepository.ApplyDelete(ctx, dataClient, &([]*Test{testRecord3, testRecord4}))
 	assert.Nil(t, err)
 }
+
+func TestMutation_buildInsertOrUpdateCommitTs(t *testing.T) {
+	assert.Equal(t, []any{"a", "b", spanner.CommitTimestamp, spanner.CommitTimestamp}, toValues(&testCommitTs{ID: "a", Name: "b"}))
+	assert.Equal(t, []any{spanner.CommitTimestamp, "b"}, toColumnValues(&testCommitTs{ID: "a", Name: "b"}, []string{"UpdatedAt", "Name"}))
+	assert.Len(t, NewMutation("CommitTs").buildInsertOrUpdate([]any{&testCommitTs{ID: "a"}}), 1)
+}
@@ -0,0 +1,137 @@
+package spnr
+
+import (
+	"cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
+)
+
+// Option is the option of the requests sent by Reader, DML and Mutation.
+// Pass it to With method of them (e.g. Reader.With(spnr.Tag("checkout"), spnr.Priority(spnr.PriorityLow))),
+// or to Options.RequestOptions to apply it to all the requests.
+type Option func(*requestOptions)
+
+// RequestPriority is the priority of the requests.
+type RequestPriority = sppb.RequestOptions_Priority
+
+const (
+	PriorityLow    RequestPriority = sppb.RequestOptions_PRIORITY_LOW
+	PriorityMedium RequestPriority = sppb.RequestOptions_PRIORITY_MEDIUM
+	PriorityHigh   RequestPriority = sppb.RequestOptions_PRIORITY_HIGH
+)
+
+// ReadLockHint is the lock hint of the reads in read-write transactions.
+type ReadLockHint = sppb.ReadRequest_LockHint
+
+const (
+	LockHintShared    ReadLockHint = sppb.ReadRequest_LOCK_HINT_SHARED
+	LockHintExclusive ReadLockHint = sppb.ReadRequest_LOCK_HINT_EXCLUSIVE
+)
+
+type requestOptions struct {
+	requestTag                 string
+	transactionTag             string
+	priority                   RequestPriority
+	limit                      int
+	optimizerVersion           string
+	optimizerStatisticsPackage string
+	lockHint                   ReadLockHint
+}
+
+// Tag sets the request tag to the requests, which can be found in the query statistics.
+func Tag(tag string) Option {
+	return func(o *requestOptions) {
+		o.requestTag = tag
+	}
+}
+
+// TransactionTag sets the transaction tag to the transactions started by spnr (e.g. Mutation.ApplyXXX).
+// It has no effect on the requests in the transactions passed to spnr, so set it to the transaction in that case.
+func TransactionTag(tag string) Option {
+	return func(o *requestOptions) {
+		o.transactionTag = tag
+	}
+}
+
+// Priority sets the RPC priority to the requests.
+func Priority(p RequestPriority) Option {
+	return func(o *requestOptions) {
+		o.priority = p
+	}
+}
+
+// Limit sets the max number of rows to read by the reads using primary keys (e.g. Reader.FindAll).
+// It has no effect on queries, so use LIMIT clause for them.
+func Limit(n int) Option {
+	return func(o *requestOptions) {
+		o.limit = n
+	}
+}
+
+// OptimizerVersion sets the query optimizer version to the queries and DML statements.
+func OptimizerVersion(version string) Option {
+	return func(o *requestOptions) {
+		o.optimizerVersion = version
+	}
+}
+
+// OptimizerStatisticsPackage sets the query optimizer statistics package to the queries and DML statements.
+func OptimizerStatisticsPackage(pkg string) Option {
+	return func(o *requestOptions) {
+		o.optimizerStatisticsPackage = pkg
+	}
+}
+
+// LockHint sets the lock hint to the reads using primary keys in read-write transactions.
+func LockHint(hint ReadLockHint) Option {
+	return func(o *requestOptions) {
+		o.lockHint = hint
+	}
+}
+
+// with returns the copy of the options applied the passed options.
+func (o requestOptions) with(opts ...Option) requestOptions {
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func newRequestOptions(opts []Option) requestOptions {
+	return requestOptions{}.with(opts...)
+}
+
+func (o requestOptions) readOptions(index string) *spanner.ReadOptions {
+	return &spanner.ReadOptions{
+		Index:      index,
+		Limit:      o.limit,
+		Priority:   o.priority,
+		RequestTag: o.requestTag,
+		LockHint:   o.lockHint,
+	}
+}
+
+func (o requestOptions) queryOptions() spanner.QueryOptions {
+	qo := spanner.QueryOptions{Priority: o.priority, RequestTag: o.requestTag}
+	if o.optimizerVersion != "" || o.optimizerStatisticsPackage != "" {
+		qo.Options = &sppb.ExecuteSqlRequest_QueryOptions{
+			OptimizerVersion:           o.optimizerVersion,
+			OptimizerStatisticsPackage: o.optimizerStatisticsPackage,
+		}
+	}
+	return qo
+}
+
+func (o requestOptions) applyOptions() []spanner.ApplyOption {
+	var opts []spanner.ApplyOption
+	if o.transactionTag != "" {
+		opts = append(opts, spanner.TransactionTag(o.transactionTag))
+	}
+	if o.priority != sppb.RequestOptions_PRIORITY_UNSPECIFIED {
+		opts = append(opts, spanner.Priority(o.priority))
+	}
+	return opts
+}
+
+func (o requestOptions) transactionOptions() spanner.TransactionOptions {
+	return spanner.TransactionOptions{TransactionTag: o.transactionTag, CommitPriority: o.priority}
+}
@@ -0,0 +1,105 @@
+package spnr
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestOptions(t *testing.T) {
+	o := newRequestOptions([]Option{Tag("default"), Priority(PriorityLow)})
+	o = o.with(Tag("checkout"), Limit(10), LockHint(LockHintExclusive), OptimizerVersion("5"))
+
+	assert.Equal(t, &spanner.ReadOptions{
+		Index:      "Index",
+		Limit:      10,
+		Priority:   PriorityLow,
+		RequestTag: "checkout",
+		LockHint:   LockHintExclusive,
+	}, o.readOptions("Index"))
+	assert.Equal(t, spanner.QueryOptions{
+		Priority:   PriorityLow,
+		RequestTag: "checkout",
+		Options:    &sppb.ExecuteSqlRequest_QueryOptions{OptimizerVersion: "5"},
+	}, o.queryOptions())
+	assert.Len(t, o.applyOptions(), 1)
+	assert.Len(t, o.with(TransactionTag("tx")).applyOptions(), 2)
+	assert.Nil(t, requestOptions{}.queryOptions().Options)
+}
+
+func TestWith(t *testing.T) {
+	m := NewMutationWithOptions("Test", &Options{RequestOptions: []Option{Tag("default")}})
+	tagged := m.With(Tag("checkout"), TransactionTag("tx"))
+	assert.Equal(t, "default", m.opts.requestTag)
+	assert.Equal(t, "checkout", tagged.opts.requestTag)
+	assert.Equal(t, "tx", tagged.Reader(context.Background(), nil).opts.transactionTag)
+
+	r := testDMLRepository.Reader(context.Background(), nil)
+	assert.Equal(t, PriorityHigh, r.With(Priority(PriorityHigh)).opts.priority)
+	assert.Equal(t, RequestPriority(0), r.opts.priority)
+
+	store := testDMLStore.With(Tag("store"))
+	assert.Equal(t, "store", store.dml.opts.requestTag)
+	assert.Equal(t, "", testDMLStore.dml.opts.requestTag)
+}
+
+func TestReaderWithOptions(t *testing.T) {
+	ctx := context.Background()
+	m := testRepository.With(Tag("test"), TransactionTag("test"), Priority(PriorityLow))
+	_, err := m.ApplyInsertOrUpdate(ctx, dataClient, &([]*Test{testRecord3, testRecord4}))
+	assert.Nil(t, err)
+
+	var records []Test
+	err = m.Reader(ctx, dataClient.Single()).With(Limit(1)).FindAll(spanner.AllKeys(), &records)
+	assert.Nil(t, err)
+	assert.Len(t, records, 1)
+
+	_, err = m.ApplyDelete(ctx, dataClient, &([]*Test{testRecord3, testRecord4}))
+	assert.Nil(t, err)
+}
+
+var (
+	_ OptionsTransaction = (*spanner.ReadOnlyTransaction)(nil)
+	_ OptionsTransaction = (*spanner.ReadWriteTransaction)(nil)
+	_ OptionsTransaction = (*StaleTransaction)(nil)
+)
+
+// testPlainTransaction implements only Transaction to check the fallback without request options.
+type testPlainTransaction struct {
+	called []string
+}
+
+var errTestPlainTransaction = errors.New("plain transaction")
+
+func (tx *testPlainTransaction) Read(ctx context.Context, table string, keys spanner.KeySet, columns []string) *spanner.RowIterator {
+	tx.called = append(tx.called, "Read")
+	return nil
+}
+
+func (tx *testPlainTransaction) ReadRow(ctx context.Context, table string, key spanner.Key, columns []string) (*spanner.Row, error) {
+	tx.called = append(tx.called, "ReadRow")
+	return nil, errTestPlainTransaction
+}
+
+func (tx *testPlainTransaction) Query(ctx context.Context, statement spanner.Statement) *spanner.RowIterator {
+	tx.called = append(tx.called, "Query")
+	return nil
+}
+
+func TestReader_plainTransaction(t *testing.T) {
+	tx := &testPlainTransaction{}
+	r := NewDML("Test").Reader(context.Background(), tx).With(Tag("ignored"))
+
+	var record Test
+	assert.ErrorIs(t, r.FindOne(spanner.Key{"a", 1}, &record), errTestPlainTransaction)
+	r.read(spanner.AllKeys(), []string{"String"})
+	r.query("SELECT 1", nil)
+	assert.Equal(t, []string{"ReadRow", "Read", "Query"}, tx.called)
+
+	var records []Test
+	assert.ErrorContains(t, r.FindAllByIndex("TestByNullString", spanner.AllKeys(), &records), "OptionsTransaction")
+}
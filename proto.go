@@ -0,0 +1,40 @@
+package spnr
+
+import (
+	"reflect"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	protoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+	protoEnumType    = reflect.TypeOf((*protoreflect.Enum)(nil)).Elem()
+)
+
+// isProtoMessage reports whether the type is a generated protobuf message (e.g. pb.SingerInfo, not *pb.SingerInfo).
+// The messages are mapped from PROTO columns by the spanner client, so they are not nested structs.
+func isProtoMessage(tp reflect.Type) bool {
+	return tp.Kind() == reflect.Struct && reflect.PointerTo(tp).Implements(protoMessageType)
+}
+
+// isProtoEnum reports whether the type is a generated protobuf enum (e.g. pb.Genre).
+func isProtoEnum(tp reflect.Type) bool {
+	return tp.Kind() == reflect.Int32 && tp.Implements(protoEnumType)
+}
+
+// decodeProtoPtr decodes PROTO or ENUM value into the pointer field (e.g. *pb.SingerInfo, *pb.Genre).
+// The spanner client doesn't accept the pointer of them as the destination, so it's allocated here.
+func decodeProtoPtr(gcv spanner.GenericColumnValue, fv reflect.Value) error {
+	if isNull(gcv) {
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+	e := reflect.New(fv.Type().Elem())
+	if err := gcv.Decode(e.Interface()); err != nil {
+		return err
+	}
+	fv.Set(e)
+	return nil
+}
@@ -0,0 +1,57 @@
+package spnr
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type testProto struct {
+	Msg    *durationpb.Duration   `spanner:"Msg"`
+	NilMsg *durationpb.Duration   `spanner:"NilMsg"`
+	Enum   structpb.NullValue     `spanner:"Enum"`
+	PEnum  *structpb.NullValue    `spanner:"PEnum"`
+	Msgs   []*durationpb.Duration `spanner:"Msgs"`
+	Enums  []structpb.NullValue   `spanner:"Enums"`
+}
+
+func TestProto(t *testing.T) {
+	assert.Nil(t, Validate(&testProto{}))
+
+	enum := structpb.NullValue_NULL_VALUE
+	record := testProto{
+		Msg:   durationpb.New(time.Second),
+		PEnum: &enum,
+		Msgs:  []*durationpb.Duration{durationpb.New(time.Minute)},
+		Enums: []structpb.NullValue{structpb.NullValue_NULL_VALUE},
+	}
+	row, err := spanner.NewRow(toColumnNames(reflect.TypeOf(record)), toValues(&record))
+	assert.Nil(t, err)
+
+	var decoded testProto
+	assert.Nil(t, decodeRow(row, &decoded))
+	assert.True(t, proto.Equal(record.Msg, decoded.Msg))
+	assert.Nil(t, decoded.NilMsg)
+	assert.Equal(t, record.Enum, decoded.Enum)
+	assert.Equal(t, enum, *decoded.PEnum)
+	assert.Len(t, decoded.Msgs, 1)
+	assert.True(t, proto.Equal(record.Msgs[0], decoded.Msgs[0]))
+	assert.Equal(t, record.Enums, decoded.Enums)
+
+	// protobuf messages are not mapped from STRUCT
+	assert.Same(t, record.Msg, encodeParams(map[string]any{"msg": record.Msg})["msg"])
+
+	type invalid struct {
+		Msg  durationpb.Duration   `spanner:"Msg"`
+		Msgs []durationpb.Duration `spanner:"Msgs"`
+	}
+	var vErr *ValidationError
+	assert.ErrorAs(t, Validate(&invalid{}), &vErr)
+	assert.Len(t, vErr.Errors, 2)
+}
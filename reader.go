@@ -27,6 +27,16 @@ type Transaction interface {
 	Query(ctx context.Context, statement spanner.Statement) *spanner.RowIterator
 }
 
+// OptionsTransaction is the optional interface of Transaction to send the requests with the request options (see Reader.With).
+// The transactions of the spanner client implement it.
+// If a Transaction doesn't implement it, the request options are ignored and the index reads are not supported.
+type OptionsTransaction interface {
+	Transaction
+	ReadWithOptions(ctx context.Context, table string, keys spanner.KeySet, columns []string, opts *spanner.ReadOptions) *spanner.RowIterator
+	ReadRowWithOptions(ctx context.Context, table string, key spanner.Key, columns []string, opts *spanner.ReadOptions) (*spanner.Row, error)
+	QueryWithOptions(ctx context.Context, statement spanner.Statement, opts spanner.QueryOptions) *spanner.RowIterator
+}
+
 // Reader executes read operations.
 type Reader struct {
 	table      string
@@ -34,6 +44,42 @@ type Reader struct {
 	tx         Transaction
 	logger     logger
 	logEnabled bool
+	strict     bool
+	pageKey    []byte
+	indexes    map[string][]string
+	opts       requestOptions
+	softDelete string
+}
+
+// With returns the copy of Reader which sends the requests with the passed options.
+//
+//	reader.With(spnr.Tag("checkout"), spnr.Priority(spnr.PriorityLow)).FindOne(key, &singer)
+func (r *Reader) With(opts ...Option) *Reader {
+	c := *r
+	c.opts = r.opts.with(opts...)
+	return &c
+}
+
+func (r *Reader) read(keys spanner.KeySet, columns []string) *spanner.RowIterator {
+	if tx, ok := r.tx.(OptionsTransaction); ok {
+		return tx.ReadWithOptions(r.ctx, r.table, keys, columns, r.opts.readOptions(""))
+	}
+	return r.tx.Read(r.ctx, r.table, keys, columns)
+}
+
+func (r *Reader) readRow(key spanner.Key, columns []string) (*spanner.Row, error) {
+	if tx, ok := r.tx.(OptionsTransaction); ok {
+		return tx.ReadRowWithOptions(r.ctx, r.table, key, columns, r.opts.readOptions(""))
+	}
+	return r.tx.ReadRow(r.ctx, r.table, key, columns)
+}
+
+func (r *Reader) query(sql string, params map[string]any) *spanner.RowIterator {
+	stmt := spanner.Statement{SQL: sql, Params: encodeParams(params)}
+	if tx, ok := r.tx.(OptionsTransaction); ok {
+		return tx.QueryWithOptions(r.ctx, stmt, r.opts.queryOptions())
+	}
+	return r.tx.Query(r.ctx, stmt)
 }
 
 func (r *Reader) logf(format string, v ...any) {
@@ -47,13 +93,10 @@ func (r *Reader) logf(format string, v ...any) {
 	}
 }
 
+// toColumnNames returns the columns to read for the struct type.
+// The columns are resolved from spanner tags in the same way as write operations.
 func toColumnNames(val reflect.Type) []string {
-	var columns []string
-
-	for i := 0; i < val.NumField(); i++ {
-		columns = append(columns, val.Field(i).Name)
-	}
-	return columns
+	return getStructInfo(val).columns
 }
 
 func isNotFound(err error) bool {
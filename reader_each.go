@@ -0,0 +1,80 @@
+package spnr
+
+import (
+	"context"
+	"reflect"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
+)
+
+// QueryEach fetches records by calling specified query, and calls fn for each record one by one.
+// Unlike Reader.Query, it doesn't hold all the records in memory, so it's suitable for reading a large number of records.
+// If fn returns an error, the iteration stops and the error is returned as it is.
+// The struct passed to fn is allocated for each record, so it's safe to keep it after fn returns.
+func QueryEach[T any](r *Reader, sql string, params map[string]any, fn func(*T) error) error {
+	if err := validateEachType[T](r); err != nil {
+		return err
+	}
+	r.logf(readLogTemplate, "sql:"+sql, params)
+	return eachRow(r.query(sql, params), decodeAny, fn)
+}
+
+// FindEach fetches records by specified a set of primary keys, and calls fn for each record one by one.
+// The soft deleted records are skipped in the same way as Reader.FindAll.
+// See QueryEach for the details.
+func FindEach[T any](r *Reader, keys spanner.KeySet, fn func(*T) error) error {
+	if err := validateEachType[T](r); err != nil {
+		return err
+	}
+	r.logf(readLogTemplate, "table:"+r.table, keys)
+	columns, decode := r.readColumns(toColumnNames(reflect.TypeOf((*T)(nil)).Elem()))
+	return eachRow(r.read(keys, columns), decode, fn)
+}
+
+// QueryEach fetches records by calling specified query, and calls fn for each record one by one.
+// See spnr.QueryEach for the details.
+func (s *Store[T]) QueryEach(ctx context.Context, tx Transaction, sql string, params map[string]any, fn func(*T) error) error {
+	return QueryEach(s.Reader(ctx, tx), sql, params, fn)
+}
+
+// FindEach fetches records by specified a set of primary keys, and calls fn for each record one by one.
+// See spnr.QueryEach for the details.
+func (s *Store[T]) FindEach(ctx context.Context, tx Transaction, keys spanner.KeySet, fn func(*T) error) error {
+	return FindEach(s.Reader(ctx, tx), keys, fn)
+}
+
+func validateEachType[T any](r *Reader) error {
+	var t T
+	if err := validateStructType(&t); err != nil {
+		return err
+	}
+	return validateTags(&t, r.strict)
+}
+
+// eachRow maps the rows into T one by one by decode and calls fn for each of them.
+// rows is always stopped when it returns.
+func eachRow[T any](rows *spanner.RowIterator, decode rowDecoder, fn func(*T) error) error {
+	defer rows.Stop()
+	for {
+		row, err := rows.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		t := new(T)
+		alive, err := decode(row, t)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if !alive {
+			continue
+		}
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package spnr
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryEach(t *testing.T) {
+	ctx := context.Background()
+	_, err := testRepository.ApplyInsertOrUpdate(ctx, dataClient, &([]*Test{testRecord3, testRecord4}))
+	assert.Nil(t, err)
+
+	var fetched []*Test
+	err = QueryEach(testRepository.Reader(ctx, dataClient.Single()), "select * from Test order by `String`", nil, func(r *Test) error {
+		fetched = append(fetched, r)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Len(t, fetched, 2)
+	assert.Equal(t, testRecord3.String, fetched[0].String)
+	assert.Equal(t, testRecord4.String, fetched[1].String)
+
+	errStop := errors.New("stop")
+	var cnt int
+	keys := spanner.KeySetFromKeys(spanner.Key{testRecord3.String, testRecord3.Int64}, spanner.Key{testRecord4.String, testRecord4.Int64})
+	err = testMutationStore.FindEach(ctx, dataClient.Single(), keys, func(r *Test) error {
+		cnt++
+		return errStop
+	})
+	assert.Equal(t, errStop, err)
+	assert.Equal(t, 1, cnt)
+
+	_, err = testRepository.ApplyDelete(ctx, dataClient, &([]*Test{testRecord3, testRecord4}))
+	assert.Nil(t, err)
+}
+
+func TestQueryEachInvalidType(t *testing.T) {
+	err := QueryEach(testRepository.Reader(context.Background(), nil), "select 1", nil, func(*int64) error { return nil })
+	assert.NotNil(t, err)
+}
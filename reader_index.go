@@ -0,0 +1,183 @@
+package spnr
+
+import (
+	"reflect"
+	"strings"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
+)
+
+// FindOneByIndex fetches a record by specified key of the secondary index, and map the record into the passed pointer of struct.
+// If no record is found, this method will return ErrNotFound.
+// If multiple records are found (the index is not unique), this method will return ErrMoreThanOneRecordFound.
+// See FindAllByIndex for the details.
+func (r *Reader) FindOneByIndex(index string, key spanner.Key, target any) error {
+	if err := validateStructType(target); err != nil {
+		return err
+	}
+	slice := reflect.New(reflect.SliceOf(reflect.TypeOf(target).Elem()))
+	if err := r.FindAllByIndex(index, key, slice.Interface()); err != nil {
+		return err
+	}
+	switch slice.Elem().Len() {
+	case 0:
+		return ErrNotFound
+	case 1:
+		reflect.ValueOf(target).Elem().Set(slice.Elem().Index(0))
+		return nil
+	default:
+		return ErrMoreThanOneRecordFound
+	}
+}
+
+/*
+FindAllByIndex fetches records by specified keys of the secondary index, and map the records into the passed pointer of slice of structs.
+The records are in the order of the index.
+
+If the index stores all the columns of the struct (declared in Options.Indexes), the records are read only from the index.
+Otherwise, the primary keys are read from the index first, and then the records are read from the base table by the primary keys.
+Reading the base table requires the second read, so it returns an error for a single-use transaction (e.g. client.Single()).
+Use a multi-use read-only transaction, a read-write transaction or StaleTransaction in that case.
+If Options.SoftDeleteColumn is specified, the soft deleted records are skipped, and the index must also store the soft delete column to be covering.
+*/
+func (r *Reader) FindAllByIndex(index string, keys spanner.KeySet, target any) error {
+	if err := validateStructSliceType(target); err != nil {
+		return err
+	}
+	if err := validateTags(target, r.strict); err != nil {
+		return err
+	}
+	r.logf(readLogTemplate, "table:"+r.table+", index:"+index, keys)
+	slice := reflect.ValueOf(target).Elem()
+	innerType := slice.Type().Elem()
+	si := getStructInfo(innerType)
+
+	columns, decode := r.readColumns(si.columns)
+	if r.isCovering(index, si) {
+		return r.readUsingIndex(index, keys, columns, func(row *spanner.Row) error {
+			e := reflect.New(innerType).Elem()
+			alive, err := decode(row, e.Addr().Interface())
+			if err != nil || !alive {
+				return err
+			}
+			slice.Set(reflect.Append(slice, e))
+			return nil
+		})
+	}
+
+	if len(si.pks) == 0 {
+		return errors.Errorf("%s has no pk tags to read the base table", innerType)
+	}
+	if isSingleUse(r.tx) {
+		return errors.Errorf("index %s doesn't store all the columns of %s, and the base table cannot be read by a single-use transaction", index, innerType)
+	}
+	pkColumns := make([]string, 0, len(si.pks))
+	for _, pk := range si.pks {
+		pkColumns = append(pkColumns, pk.name)
+	}
+	var pks []spanner.Key
+	err := r.readUsingIndex(index, keys, pkColumns, func(row *spanner.Row) error {
+		key := make(spanner.Key, 0, len(si.pks))
+		for i, pk := range si.pks {
+			var gcv spanner.GenericColumnValue
+			if err := row.Column(i, &gcv); err != nil {
+				return err
+			}
+			v := reflect.New(pk.typ).Elem()
+			if err := decodeField(pk, gcv, v); err != nil {
+				return err
+			}
+			key = append(key, encodeField(pk, v))
+		}
+		pks = append(pks, key)
+		return nil
+	})
+	if err != nil || len(pks) == 0 {
+		return err
+	}
+
+	found := map[string]reflect.Value{}
+	rows := r.read(spanner.KeySetFromKeys(pks...), columns)
+	defer rows.Stop()
+	for {
+		row, err := rows.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		e := reflect.New(innerType)
+		alive, err := decode(row, e.Interface())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if !alive {
+			continue
+		}
+		found[toKey(e).String()] = e.Elem()
+	}
+	// keep the order of the index
+	for _, pk := range pks {
+		if e, ok := found[pk.String()]; ok {
+			slice.Set(reflect.Append(slice, e))
+		}
+	}
+	return nil
+}
+
+func (r *Reader) readUsingIndex(index string, keys spanner.KeySet, columns []string, fn func(*spanner.Row) error) error {
+	tx, ok := r.tx.(OptionsTransaction)
+	if !ok {
+		return errors.Errorf("index reads require the transaction implementing OptionsTransaction but got %T", r.tx)
+	}
+	rows := tx.ReadWithOptions(r.ctx, r.table, keys, columns, r.opts.readOptions(index))
+	defer rows.Stop()
+	for {
+		row, err := rows.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := fn(row); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+}
+
+// isCovering reports whether the index stores all the columns of the struct (and the soft delete column if it's specified.)
+// The primary key columns are always stored in the index.
+func (r *Reader) isCovering(index string, si *structInfo) bool {
+	stored, ok := r.indexes[index]
+	if !ok {
+		return false
+	}
+	columns := map[string]bool{}
+	for _, c := range stored {
+		columns[strings.ToLower(c)] = true
+	}
+	for _, pk := range si.pks {
+		columns[strings.ToLower(pk.name)] = true
+	}
+	for _, c := range si.columns {
+		if !columns[strings.ToLower(c)] {
+			return false
+		}
+	}
+	return r.softDelete == "" || columns[strings.ToLower(r.softDelete)]
+}
+
+// isSingleUse reports whether the transaction is a single-use read-only transaction (e.g. client.Single()), which allows only one read.
+// The spanner client doesn't expose it, so the unexported field is checked.
+func isSingleUse(tx Transaction) bool {
+	ro, ok := tx.(*spanner.ReadOnlyTransaction)
+	if !ok || ro == nil {
+		return false
+	}
+	f := reflect.ValueOf(ro).Elem().FieldByName("singleUse")
+	return f.IsValid() && f.Kind() == reflect.Bool && f.Bool()
+}
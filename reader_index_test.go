@@ -0,0 +1,74 @@
+package spnr
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+)
+
+type testIndexed struct {
+	String     string             `spanner:"String" pk:"1"`
+	Int64      int64              `spanner:"Int64" pk:"2"`
+	NullString spanner.NullString `spanner:"NullString"`
+	Float64    float64            `spanner:"Float64"`
+}
+
+func TestReader_isCovering(t *testing.T) {
+	r := &Reader{indexes: map[string][]string{"TestByNullString": {"NullString", "Float64"}}}
+	assert.True(t, r.isCovering("TestByNullString", getStructInfo(reflect.TypeOf(testIndexed{}))))
+	assert.False(t, r.isCovering("TestByNullString", getStructInfo(reflect.TypeOf(Test{}))))
+	assert.False(t, r.isCovering("Unknown", getStructInfo(reflect.TypeOf(testIndexed{}))))
+}
+
+func TestIsSingleUse(t *testing.T) {
+	assert.False(t, isSingleUse(&spanner.ReadOnlyTransaction{}))
+	assert.False(t, isSingleUse(NewStaleTransaction(nil, spanner.StrongRead())))
+	assert.False(t, isSingleUse(nil))
+}
+
+func TestFindAllByIndex(t *testing.T) {
+	ctx := context.Background()
+	_, err := testRepository.ApplyInsertOrUpdate(ctx, dataClient, &([]*Test{testRecord3, testRecord4}))
+	assert.Nil(t, err)
+
+	// non-covering reads the index and the base table in the multi-use transaction
+	tx := dataClient.ReadOnlyTransaction()
+	defer tx.Close()
+	var records []Test
+	err = testRepository.Reader(ctx, tx).FindAllByIndex("TestByNullString", spanner.AllKeys(), &records)
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+
+	assert.Equal(t, testRecord3.Bytes, records[0].Bytes)
+
+	// both records have the same NullString
+	var record Test
+	err = testRepository.Reader(ctx, tx).FindOneByIndex("TestByNullString", spanner.Key{testRecord4.NullString}, &record)
+	assert.Equal(t, ErrMoreThanOneRecordFound, err)
+
+	// StaleTransaction uses a new single-use transaction for each read
+	records = nil
+	err = testRepository.SingleReader(ctx, dataClient).FindAllByIndex("TestByNullString", spanner.AllKeys(), &records)
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+
+	// single-use transaction cannot read the base table
+	err = testRepository.Reader(ctx, dataClient.Single()).FindAllByIndex("TestByNullString", spanner.AllKeys(), &records)
+	assert.ErrorContains(t, err, "single-use transaction")
+
+	// covering
+	store := NewMutationStoreWithOptions[testIndexed]("Test", &Options{Indexes: map[string][]string{"TestByNullString": {"NullString", "Float64"}}})
+	indexed, err := store.FindAllByIndex(ctx, dataClient.Single(), "TestByNullString", spanner.Key{testRecord3.NullString})
+	assert.Nil(t, err)
+	assert.Len(t, indexed, 2)
+	assert.Equal(t, testRecord3.Float64, indexed[0].Float64)
+
+	_, err = store.FindOneByIndex(ctx, dataClient.Single(), "TestByNullString", spanner.Key{"notExist"})
+	assert.Equal(t, ErrNotFound, err)
+
+	_, err = testRepository.ApplyDelete(ctx, dataClient, &([]*Test{testRecord3, testRecord4}))
+	assert.Nil(t, err)
+}
@@ -9,34 +9,51 @@ import (
 )
 
 // FindOne fetches a record by specified primary key, and map the record into the passed pointer of struct.
+// If Options.SoftDeleteColumn is specified, the soft deleted record is treated as not found (use WithDeleted to read it.)
 func (r *Reader) FindOne(key spanner.Key, target any) error {
 	if err := validateStructType(target); err != nil {
 		return err
 	}
+	if err := validateTags(target, r.strict); err != nil {
+		return err
+	}
 	r.logf(readLogTemplate, "table:"+r.table, key)
 
-	row, err := r.tx.ReadRow(r.ctx, r.table, key, toColumnNames(reflect.ValueOf(target).Elem().Type()))
+	columns, decode := r.readColumns(toColumnNames(reflect.ValueOf(target).Elem().Type()))
+	row, err := r.readRow(key, columns)
 	if err != nil {
 		if isNotFound(err) {
 			return ErrNotFound
 		}
 		return errors.WithStack(err)
 	}
-	return row.ToStruct(target)
+	alive, err := decode(row, target)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if !alive {
+		return ErrNotFound
+	}
+	return nil
 }
 
 // FindAll fetches records by specified a set of primary keys, and map the records into the passed pointer of slice of structs.
+// If Options.SoftDeleteColumn is specified, the soft deleted records are skipped (use WithDeleted to read them.)
 func (r *Reader) FindAll(keys spanner.KeySet, target any) error {
 	if err := validateStructSliceType(target); err != nil {
 		return err
 	}
+	if err := validateTags(target, r.strict); err != nil {
+		return err
+	}
 	if r.logEnabled {
 		r.logger.Printf(readLogTemplate, "table:"+r.table, keys)
 	}
 	slice := reflect.ValueOf(target).Elem()
 	innerType := slice.Type().Elem()
 
-	rows := r.tx.Read(r.ctx, r.table, keys, toColumnNames(innerType))
+	columns, decode := r.readColumns(toColumnNames(innerType))
+	rows := r.read(keys, columns)
 	defer rows.Stop()
 	for {
 		row, err := rows.Next()
@@ -47,9 +64,13 @@ func (r *Reader) FindAll(keys spanner.KeySet, target any) error {
 			return errors.WithStack(err)
 		}
 		e := reflect.New(innerType).Elem()
-		if err := row.ToStruct(e.Addr().Interface()); err != nil {
+		alive, err := decode(row, e.Addr().Interface())
+		if err != nil {
 			return errors.WithStack(err)
 		}
+		if !alive {
+			continue
+		}
 		slice.Set(reflect.Append(slice, e))
 	}
 
@@ -64,22 +85,33 @@ Caution:
 It maps fetched column to the passed pointer by just calling spanner.Row.Columns method.
 So the type of passed value to map should be compatible to this method.
 For example if you fetch an INT64 column from spanner, you need to map this value to int64, not int.
+
+If Options.SoftDeleteColumn is specified, the soft deleted record is treated as not found in the same way as FindOne.
 */
 func (r *Reader) GetColumn(key spanner.Key, column string, target any) error {
 	r.logf(readLogTemplate, "table:"+r.table, key)
-	row, err := r.tx.ReadRow(r.ctx, r.table, key, []string{column})
+	columns, idx := r.valueColumns(column)
+	row, err := r.readRow(key, columns)
 	if err != nil {
 		if isNotFound(err) {
 			return ErrNotFound
 		}
 		return errors.WithStack(err)
 	}
-	return errors.WithStack(row.Columns(target))
+	deleted, err := isSoftDeleted(row, idx)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		return ErrNotFound
+	}
+	return errors.WithStack(row.Column(0, target))
 }
 
 // GetColumn fetches the specified column for the records that matches specified set of primary keys,
 // and map the column into the passed pointer of a slice of values.
 // Please see the caution commented in GetColumn to check type compatibility.
+// The soft deleted records are skipped in the same way as FindAll.
 func (r *Reader) GetColumnAll(keys spanner.KeySet, column string, target any) error {
 	if err := validateSliceType(target); err != nil {
 		return err
@@ -88,7 +120,8 @@ func (r *Reader) GetColumnAll(keys spanner.KeySet, column string, target any) er
 	slice := reflect.ValueOf(target).Elem()
 	innerType := slice.Type().Elem()
 
-	rows := r.tx.Read(r.ctx, r.table, keys, []string{column})
+	columns, idx := r.valueColumns(column)
+	rows := r.read(keys, columns)
 	defer rows.Stop()
 	for {
 		row, err := rows.Next()
@@ -98,8 +131,15 @@ func (r *Reader) GetColumnAll(keys spanner.KeySet, column string, target any) er
 		if err != nil {
 			return errors.WithStack(err)
 		}
+		deleted, err := isSoftDeleted(row, idx)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			continue
+		}
 		e := reflect.New(innerType).Elem()
-		if err := row.Columns(e.Addr().Interface()); err != nil {
+		if err := row.Column(0, e.Addr().Interface()); err != nil {
 			return errors.WithStack(err)
 		}
 		slice.Set(reflect.Append(slice, e))
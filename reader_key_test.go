@@ -4,6 +4,7 @@ import (
 	"cloud.google.com/go/spanner"
 	"context"
 	"github.com/stretchr/testify/assert"
+	"reflect"
 	"testing"
 )
 
@@ -100,3 +101,38 @@ func cleanUpReadTest(ctx context.Context) error {
 	_, err := testRepository.ApplyDelete(ctx, dataClient, &ls)
 	return err
 }
+
+type testRenamed struct {
+	ID       string             `spanner:"String" pk:"1"`
+	Num      int64              `spanner:"Int64" pk:"2"`
+	Nullable spanner.NullString `spanner:"NullString"`
+	Ignored  string             `spanner:"-"`
+	Helper   string
+	private  string `spanner:"Private"` //nolint:unused
+}
+
+func TestToColumnNames(t *testing.T) {
+	assert.Equal(t, []string{"String", "Int64", "NullString"}, toColumnNames(reflect.TypeOf(testRenamed{})))
+}
+
+func TestFindOneRenamed(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, prepareReadTest(ctx))
+
+	var fetched testRenamed
+	err := testRepository.Reader(ctx, dataClient.Single()).FindOne(spanner.Key{testRecord1.String, testRecord1.Int64}, &fetched)
+	assert.Nil(t, err)
+	assert.Equal(t, testRecord1.String, fetched.ID)
+	assert.Equal(t, testRecord1.Int64, fetched.Num)
+	assert.Equal(t, testRecord1.NullString, fetched.Nullable)
+	assert.Empty(t, fetched.Helper)
+
+	var fetchedAll []testRenamed
+	keys := spanner.KeySetFromKeys(spanner.Key{testRecord1.String, testRecord1.Int64}, spanner.Key{testRecord2.String, testRecord2.Int64})
+	err = testRepository.Reader(ctx, dataClient.Single()).FindAll(keys, &fetchedAll)
+	assert.Nil(t, err)
+	assert.Len(t, fetchedAll, 2)
+	assert.Equal(t, testRecord2.String, fetchedAll[1].ID)
+
+	assert.Nil(t, cleanUpReadTest(ctx))
+}
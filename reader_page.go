@@ -0,0 +1,291 @@
+package spnr
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidPageToken is returned by Reader.Page when the passed token is malformed, tampered or made for another query.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// ErrPageTokenKeyRequired is returned by Reader.Page when a token is passed or issued but Options.PageTokenKey is not specified.
+var ErrPageTokenKeyRequired = errors.New("page token key is not specified by Options.PageTokenKey")
+
+// the params added by Page are prefixed so that they don't collide with PageOptions.Params
+const (
+	pageParamPrefix = "__spnr_"
+	pageSizeParam   = pageParamPrefix + "page_size"
+	pageKeyParam    = pageParamPrefix + "page_key"
+)
+
+// PageOptions is the options for Reader.Page.
+type PageOptions struct {
+	// Size is the max number of records in a page.
+	Size int
+	// Token is the token returned by the previous page. Leave it empty to read the first page.
+	Token string
+	// Backward reads the records in the reverse order of the primary key.
+	// The token must be used with the same direction as the page which returned it.
+	Backward bool
+	// Where is an optional filter added to the query like "`Status`=@status".
+	// The token must be used with the same filter as the page which returned it.
+	Where string
+	// Params is the params used in Where. The names starting with "__spnr_" are reserved by Page.
+	// The token must be used with the same params as the page which returned it.
+	Params map[string]any
+}
+
+type pageToken struct {
+	Key      []json.RawMessage `json:"k"`
+	Backward bool              `json:"b,omitempty"`
+	Scope    string            `json:"s"`
+}
+
+/*
+Page fetches a page of records ordered by the primary key, and map the records into the passed pointer of a slice of struct.
+The query is built from the pk tags of the struct like the following (keyset pagination).
+
+	SELECT `SingerId`, `AlbumId`, `Title` FROM `Albums`
+	WHERE (<Where>) AND ((`SingerId` > @__spnr_page_key_0) OR (`SingerId` = @__spnr_page_key_0 AND `AlbumId` > @__spnr_page_key_1))
+	ORDER BY `SingerId` ASC, `AlbumId` ASC LIMIT @__spnr_page_size
+
+It returns the token to read the next page, which is empty if there are no more records.
+If Options.SoftDeleteColumn is specified, the soft deleted records are excluded by adding "`DeletedAt` IS NULL" to the conditions.
+The token is signed with Options.PageTokenKey, so the token tampered or made for another table, filter or params is rejected with ErrInvalidPageToken.
+Use the same key in all the processes serving the tokens. If it's not specified, ErrPageTokenKeyRequired is returned when a token is passed or issued.
+*/
+func (r *Reader) Page(opts PageOptions, target any) (nextToken string, err error) {
+	if err := validateStructSliceType(target); err != nil {
+		return "", err
+	}
+	if err := validateTags(target, r.strict); err != nil {
+		return "", err
+	}
+	if opts.Size <= 0 {
+		return "", errors.Errorf("page size must be positive but got %d", opts.Size)
+	}
+	slice := reflect.ValueOf(target).Elem()
+	innerType := slice.Type().Elem()
+	si := getStructInfo(innerType)
+	if len(si.pks) == 0 {
+		return "", errors.Errorf("%s has no pk tags", innerType)
+	}
+
+	params := map[string]any{pageSizeParam: int64(opts.Size + 1)}
+	for k, v := range opts.Params {
+		if strings.HasPrefix(k, pageParamPrefix) {
+			return "", errors.Errorf("param name %s is reserved by Page", k)
+		}
+		params[k] = v
+	}
+	var key []any
+	if opts.Token != "" {
+		key, err = r.decodePageToken(opts, si)
+		if err != nil {
+			return "", err
+		}
+		for i, v := range key {
+			params[addIdx(pageKeyParam, i)] = v
+		}
+	}
+
+	slice.Set(slice.Slice(0, 0))
+	if err := r.Query(buildPageQuery(r.table, si, opts, key != nil, r.aliveCond()), params, target); err != nil {
+		return "", err
+	}
+	if slice.Len() <= opts.Size {
+		return "", nil
+	}
+	// one more record than the page size is fetched to know whether the next page exists
+	slice.Set(slice.Slice(0, opts.Size))
+	return r.encodePageToken(opts, toFieldKey(slice.Index(opts.Size-1)))
+}
+
+func buildPageQuery(table string, si *structInfo, opts PageOptions, hasToken bool, aliveCond string) string {
+	columns := make([]string, 0, len(si.columns))
+	for _, c := range si.columns {
+		columns = append(columns, quote(c))
+	}
+
+	var conds []string
+	if opts.Where != "" {
+		conds = append(conds, "("+opts.Where+")")
+	}
+	if aliveCond != "" {
+		conds = append(conds, aliveCond)
+	}
+	if hasToken {
+		conds = append(conds, "("+buildKeysetCond(si.pks, opts.Backward)+")")
+	}
+	var where string
+	if len(conds) > 0 {
+		where = " WHERE " + strings.Join(conds, " AND ")
+	}
+
+	orders := make([]string, 0, len(si.pks))
+	for _, pk := range si.pks {
+		// the records are read in the order of the primary key of the table, and reversed for Backward
+		if pk.pkDesc != opts.Backward {
+			orders = append(orders, quote(pk.name)+" DESC")
+		} else {
+			orders = append(orders, quote(pk.name)+" ASC")
+		}
+	}
+
+	return fmt.Sprintf("SELECT %s FROM %s%s ORDER BY %s LIMIT %s",
+		strings.Join(columns, ", "),
+		quote(table),
+		where,
+		strings.Join(orders, ", "),
+		addPlaceHolder(pageSizeParam),
+	)
+}
+
+// buildKeysetCond builds the condition to read the records after the key.
+// Spanner doesn't support tuple comparison, so (a, b) > (@a, @b) is expanded to (a > @a) OR (a = @a AND b > @b).
+func buildKeysetCond(pks []fieldInfo, backward bool) string {
+	var ors []string
+	for i, pk := range pks {
+		var ands []string
+		for j := 0; j < i; j++ {
+			ands = append(ands, quote(pks[j].name)+" = "+addPlaceHolder(addIdx(pageKeyParam, j)))
+		}
+		op := ">"
+		if pk.pkDesc != backward {
+			op = "<"
+		}
+		ands = append(ands, quote(pk.name)+" "+op+" "+addPlaceHolder(addIdx(pageKeyParam, i)))
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+	return strings.Join(ors, " OR ")
+}
+
+// pageScope identifies the query which the token is made for by the table, the filter and the params.
+func (r *Reader) pageScope(opts PageOptions) string {
+	h := sha256.New()
+	h.Write([]byte(r.table + "\x00" + opts.Where))
+	names := make([]string, 0, len(opts.Params))
+	for k := range opts.Params {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		h.Write([]byte("\x00" + k + "=" + canonicalParam(opts.Params[k])))
+	}
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:8])
+}
+
+// canonicalParam returns the string representation of the param value which doesn't depend on its address.
+func canonicalParam(v any) string {
+	if b, err := json.Marshal(v); err == nil {
+		return fmt.Sprintf("%T:%s", v, b)
+	}
+	return fmt.Sprintf("%T:%v", v, v)
+}
+
+// toFieldKey returns the primary key of the struct as the values of the fields.
+// Unlike toKey, the values are not encoded by the codecs, so that they can be decoded into the fields from the token.
+func toFieldKey(val reflect.Value) []any {
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	si := getStructInfo(val.Type())
+	key := make([]any, 0, len(si.pks))
+	for _, f := range si.pks {
+		key = append(key, fieldByIndex(val, f).Interface())
+	}
+	return key
+}
+
+func (r *Reader) encodePageToken(opts PageOptions, key []any) (string, error) {
+	token := pageToken{Backward: opts.Backward, Scope: r.pageScope(opts)}
+	for _, v := range key {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		token.Key = append(token.Key, b)
+	}
+	payload, err := json.Marshal(token)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	sig, err := r.signPageToken(payload)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+func (r *Reader) decodePageToken(opts PageOptions, si *structInfo) ([]any, error) {
+	if len(r.pageKey) == 0 {
+		return nil, ErrPageTokenKeyRequired
+	}
+	parts := strings.Split(opts.Token, ".")
+	if len(parts) != 2 {
+		return nil, ErrInvalidPageToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	expected, err := r.signPageToken(payload)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(sig, expected) {
+		return nil, ErrInvalidPageToken
+	}
+
+	var token pageToken
+	if err := json.Unmarshal(payload, &token); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	if token.Scope != r.pageScope(opts) || token.Backward != opts.Backward || len(token.Key) != len(si.pks) {
+		return nil, ErrInvalidPageToken
+	}
+	key := make([]any, 0, len(si.pks))
+	for i, pk := range si.pks {
+		// decode the value into the type of the field so that it's encoded to the same type as the column
+		v := reflect.New(pk.typ)
+		if err := json.Unmarshal(token.Key[i], v.Interface()); err != nil {
+			return nil, ErrInvalidPageToken
+		}
+		key = append(key, encodeField(pk, v.Elem()))
+	}
+	return key, nil
+}
+
+// signPageToken signs the payload with Options.PageTokenKey.
+// There is no default key, since the tokens signed by a process-local key are rejected by the other processes.
+func (r *Reader) signPageToken(payload []byte) ([]byte, error) {
+	if len(r.pageKey) == 0 {
+		return nil, ErrPageTokenKeyRequired
+	}
+	mac := hmac.New(sha256.New, r.pageKey)
+	mac.Write(payload)
+	return mac.Sum(nil), nil
+}
+
+// Page fetches a page of records ordered by the primary key.
+// See Reader.Page for the details.
+func (s *Store[T]) Page(ctx context.Context, tx Transaction, opts PageOptions) (records []T, nextToken string, err error) {
+	nextToken, err = s.Reader(ctx, tx).Page(opts, &records)
+	if err != nil {
+		return nil, "", err
+	}
+	return records, nextToken, nil
+}
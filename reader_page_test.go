@@ -0,0 +1,103 @@
+package spnr
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPageQuery(t *testing.T) {
+	si := getStructInfo(reflect.TypeOf(testCompositeKey{}))
+	assert.Equal(t, "SELECT `C`, `Value`, `A`, `B` FROM `T` ORDER BY `A` ASC, `B` DESC, `C` ASC LIMIT @__spnr_page_size",
+		buildPageQuery("T", si, PageOptions{}, false, ""))
+	assert.Equal(t, "SELECT `C`, `Value`, `A`, `B` FROM `T` WHERE (`Value`=@v) AND ((`A` > @__spnr_page_key_0) OR (`A` = @__spnr_page_key_0 AND `B` < @__spnr_page_key_1) OR (`A` = @__spnr_page_key_0 AND `B` = @__spnr_page_key_1 AND `C` > @__spnr_page_key_2)) ORDER BY `A` ASC, `B` DESC, `C` ASC LIMIT @__spnr_page_size",
+		buildPageQuery("T", si, PageOptions{Where: "`Value`=@v"}, true, ""))
+	assert.Equal(t, "SELECT `C`, `Value`, `A`, `B` FROM `T` WHERE ((`A` < @__spnr_page_key_0) OR (`A` = @__spnr_page_key_0 AND `B` > @__spnr_page_key_1) OR (`A` = @__spnr_page_key_0 AND `B` = @__spnr_page_key_1 AND `C` < @__spnr_page_key_2)) ORDER BY `A` DESC, `B` ASC, `C` DESC LIMIT @__spnr_page_size",
+		buildPageQuery("T", si, PageOptions{Backward: true}, true, ""))
+	assert.Equal(t, "SELECT `C`, `Value`, `A`, `B` FROM `T` WHERE (`Value`=@v) AND `DeletedAt` IS NULL ORDER BY `A` ASC, `B` DESC, `C` ASC LIMIT @__spnr_page_size",
+		buildPageQuery("T", si, PageOptions{Where: "`Value`=@v"}, false, (&Reader{softDelete: "DeletedAt"}).aliveCond()))
+}
+
+func TestPageToken(t *testing.T) {
+	si := getStructInfo(reflect.TypeOf(testCompositeKey{}))
+	r := &Reader{table: "T", pageKey: []byte("secret")}
+	opts := PageOptions{Where: "`Value`=@v", Params: map[string]any{"v": "a", "w": int64(1)}}
+	token, err := r.encodePageToken(opts, toKey(reflect.ValueOf(testCompositeKeyRecord)))
+	assert.Nil(t, err)
+
+	opts.Token = token
+	key, err := r.decodePageToken(opts, si)
+	assert.Nil(t, err)
+	assert.Equal(t, []any{"a", int64(2), "c"}, key)
+
+	tests := []struct {
+		name   string
+		reader *Reader
+		opts   PageOptions
+	}{
+		{name: "another key", reader: &Reader{table: "T", pageKey: []byte("another")}, opts: opts},
+		{name: "another table", reader: &Reader{table: "T2", pageKey: []byte("secret")}, opts: opts},
+		{name: "another filter", reader: r, opts: PageOptions{Token: token, Params: opts.Params}},
+		{name: "another param", reader: r, opts: PageOptions{Token: token, Where: opts.Where, Params: map[string]any{"v": "b", "w": int64(1)}}},
+		{name: "another param type", reader: r, opts: PageOptions{Token: token, Where: opts.Where, Params: map[string]any{"v": "a", "w": "1"}}},
+		{name: "missing param", reader: r, opts: PageOptions{Token: token, Where: opts.Where, Params: map[string]any{"v": "a"}}},
+		{name: "another direction", reader: r, opts: PageOptions{Token: token, Where: opts.Where, Params: opts.Params, Backward: true}},
+		{name: "tampered", reader: r, opts: PageOptions{Token: "e30" + token[3:], Where: opts.Where, Params: opts.Params}},
+		{name: "malformed", reader: r, opts: PageOptions{Token: strings.ReplaceAll(token, ".", ""), Where: opts.Where, Params: opts.Params}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.reader.decodePageToken(tt.opts, si)
+			assert.Equal(t, ErrInvalidPageToken, err)
+		})
+	}
+
+	// the same params in another map are accepted
+	_, err = r.decodePageToken(PageOptions{Token: token, Where: opts.Where, Params: map[string]any{"w": int64(1), "v": "a"}}, si)
+	assert.Nil(t, err)
+
+	_, err = r.Page(PageOptions{Size: 1, Params: map[string]any{"__spnr_page_size": int64(1)}}, &[]testCompositeKey{})
+	assert.ErrorContains(t, err, "reserved")
+
+	// the tokens are not signed by a process-local key
+	noKey := &Reader{table: "T"}
+	_, err = noKey.encodePageToken(opts, toKey(reflect.ValueOf(testCompositeKeyRecord)))
+	assert.Equal(t, ErrPageTokenKeyRequired, err)
+	_, err = noKey.decodePageToken(PageOptions{Token: token, Where: opts.Where, Params: opts.Params}, si)
+	assert.Equal(t, ErrPageTokenKeyRequired, err)
+}
+
+func TestPage(t *testing.T) {
+	ctx := context.Background()
+	_, err := testRepository.ApplyInsertOrUpdate(ctx, dataClient, &([]*Test{testRecord3, testRecord4}))
+	assert.Nil(t, err)
+
+	// the token can't be issued without PageTokenKey
+	_, _, err = testMutationStore.Page(ctx, dataClient.Single(), PageOptions{Size: 1})
+	assert.Equal(t, ErrPageTokenKeyRequired, err)
+
+	store := NewMutationStoreWithOptions[Test]("Test", &Options{PageTokenKey: []byte("secret")})
+	records, token, err := store.Page(ctx, dataClient.Single(), PageOptions{Size: 1})
+	assert.Nil(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, testRecord3.String, records[0].String)
+	assert.NotEmpty(t, token)
+
+	records, token, err = store.Page(ctx, dataClient.Single(), PageOptions{Size: 1, Token: token})
+	assert.Nil(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, testRecord4.String, records[0].String)
+	assert.Empty(t, token)
+
+	// no token is issued for the last page
+	records, _, err = testMutationStore.Page(ctx, dataClient.Single(), PageOptions{Size: 10, Backward: true})
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, testRecord4.String, records[0].String)
+
+	_, err = testRepository.ApplyDelete(ctx, dataClient, &([]*Test{testRecord3, testRecord4}))
+	assert.Nil(t, err)
+}
@@ -0,0 +1,252 @@
+package spnr
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"sync"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
+)
+
+// ErrNotBatchTransaction is returned by the partitioned reads when the transaction of Reader is not *spanner.BatchReadOnlyTransaction.
+var ErrNotBatchTransaction = errors.New("partitioned reads require *spanner.BatchReadOnlyTransaction")
+
+// PartitionOptions is the options of the partitioned reads (e.g. Reader.PartitionQuery).
+type PartitionOptions struct {
+	// Workers is the max number of partitions executed concurrently.
+	// runtime.NumCPU() is used if it's zero or less.
+	Workers int
+	// PartitionBytes is the desired data size for each partition.
+	PartitionBytes int64
+	// MaxPartitions is the desired maximum number of partitions.
+	MaxPartitions int64
+	// DataBoost executes the partitions using Spanner Data Boost (independent compute resources).
+	DataBoost bool
+}
+
+/*
+PartitionQuery fetches records by calling specified query using partitions, and map the records into the passed pointer of a slice of struct.
+The transaction of Reader must be *spanner.BatchReadOnlyTransaction, and the query must be root-partitionable.
+The partitions are executed concurrently by opts.Workers goroutines, so the order of the records is not guaranteed.
+
+	tx, err := client.BatchReadOnlyTransaction(ctx, spanner.StrongRead())
+	defer tx.Close()
+	var singers []Singer
+	err = singerStore.Reader(ctx, tx).PartitionQuery("select * from Singers", nil, spnr.PartitionOptions{Workers: 8, DataBoost: true}, &singers)
+*/
+func (r *Reader) PartitionQuery(sql string, params map[string]any, opts PartitionOptions, target any) error {
+	if err := validateStructSliceType(target); err != nil {
+		return err
+	}
+	if err := validateTags(target, r.strict); err != nil {
+		return err
+	}
+	r.logf(readLogTemplate, "sql:"+sql, params)
+	return r.partitionQuery(sql, params, opts, collectRows(target, decodeAny))
+}
+
+// PartitionRead fetches records by specified a set of primary keys using partitions, and map the records into the passed pointer of a slice of struct.
+// The soft deleted records are skipped in the same way as FindAll.
+// See PartitionQuery for the details.
+func (r *Reader) PartitionRead(keys spanner.KeySet, opts PartitionOptions, target any) error {
+	if err := validateStructSliceType(target); err != nil {
+		return err
+	}
+	if err := validateTags(target, r.strict); err != nil {
+		return err
+	}
+	r.logf(readLogTemplate, "table:"+r.table, keys)
+	columns, decode := r.readColumns(toColumnNames(reflect.TypeOf(target).Elem().Elem()))
+	return r.partitionRead(keys, columns, opts, collectRows(target, decode))
+}
+
+// PartitionQueryEach fetches records by calling specified query using partitions, and calls fn for each record one by one.
+// Unlike Reader.PartitionQuery, it doesn't hold all the records in memory.
+// fn is called concurrently from opts.Workers goroutines, so it must be safe for concurrent use.
+// If fn returns an error, the other partitions are cancelled and the error is returned as it is.
+func PartitionQueryEach[T any](r *Reader, sql string, params map[string]any, opts PartitionOptions, fn func(*T) error) error {
+	if err := validateEachType[T](r); err != nil {
+		return err
+	}
+	r.logf(readLogTemplate, "sql:"+sql, params)
+	return r.partitionQuery(sql, params, opts, eachRowFunc(decodeAny, fn))
+}
+
+// PartitionReadEach fetches records by specified a set of primary keys using partitions, and calls fn for each record one by one.
+// See PartitionQueryEach for the details.
+func PartitionReadEach[T any](r *Reader, keys spanner.KeySet, opts PartitionOptions, fn func(*T) error) error {
+	if err := validateEachType[T](r); err != nil {
+		return err
+	}
+	r.logf(readLogTemplate, "table:"+r.table, keys)
+	columns, decode := r.readColumns(toColumnNames(reflect.TypeOf((*T)(nil)).Elem()))
+	return r.partitionRead(keys, columns, opts, eachRowFunc(decode, fn))
+}
+
+// PartitionQuery fetches records by calling specified query using partitions.
+// See Reader.PartitionQuery for the details.
+func (s *Store[T]) PartitionQuery(ctx context.Context, tx *spanner.BatchReadOnlyTransaction, sql string, params map[string]any, opts PartitionOptions) ([]T, error) {
+	var ts []T
+	if err := s.Reader(ctx, tx).PartitionQuery(sql, params, opts, &ts); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// PartitionRead fetches records by specified a set of primary keys using partitions.
+// See Reader.PartitionQuery for the details.
+func (s *Store[T]) PartitionRead(ctx context.Context, tx *spanner.BatchReadOnlyTransaction, keys spanner.KeySet, opts PartitionOptions) ([]T, error) {
+	var ts []T
+	if err := s.Reader(ctx, tx).PartitionRead(keys, opts, &ts); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// PartitionQueryEach fetches records by calling specified query using partitions, and calls fn for each record one by one.
+// See spnr.PartitionQueryEach for the details.
+func (s *Store[T]) PartitionQueryEach(ctx context.Context, tx *spanner.BatchReadOnlyTransaction, sql string, params map[string]any, opts PartitionOptions, fn func(*T) error) error {
+	return PartitionQueryEach(s.Reader(ctx, tx), sql, params, opts, fn)
+}
+
+// PartitionReadEach fetches records by specified a set of primary keys using partitions, and calls fn for each record one by one.
+// See spnr.PartitionQueryEach for the details.
+func (s *Store[T]) PartitionReadEach(ctx context.Context, tx *spanner.BatchReadOnlyTransaction, keys spanner.KeySet, opts PartitionOptions, fn func(*T) error) error {
+	return PartitionReadEach(s.Reader(ctx, tx), keys, opts, fn)
+}
+
+func (r *Reader) partitionQuery(sql string, params map[string]any, opts PartitionOptions, fn func(*spanner.Row) error) error {
+	tx, ok := r.tx.(*spanner.BatchReadOnlyTransaction)
+	if !ok {
+		return ErrNotBatchTransaction
+	}
+	qo := r.opts.queryOptions()
+	qo.DataBoostEnabled = opts.DataBoost
+	partitions, err := tx.PartitionQueryWithOptions(r.ctx, spanner.Statement{SQL: sql, Params: encodeParams(params)}, opts.spannerOptions(), qo)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return executePartitions(r.ctx, tx, partitions, opts.workers(), fn)
+}
+
+func (r *Reader) partitionRead(keys spanner.KeySet, columns []string, opts PartitionOptions, fn func(*spanner.Row) error) error {
+	tx, ok := r.tx.(*spanner.BatchReadOnlyTransaction)
+	if !ok {
+		return ErrNotBatchTransaction
+	}
+	ro := r.opts.readOptions("")
+	// limit is not supported by the partitioned reads
+	ro.Limit = 0
+	ro.DataBoostEnabled = opts.DataBoost
+	partitions, err := tx.PartitionReadWithOptions(r.ctx, r.table, keys, columns, opts.spannerOptions(), *ro)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return executePartitions(r.ctx, tx, partitions, opts.workers(), fn)
+}
+
+func (o PartitionOptions) spannerOptions() spanner.PartitionOptions {
+	return spanner.PartitionOptions{PartitionBytes: o.PartitionBytes, MaxPartitions: o.MaxPartitions}
+}
+
+func (o PartitionOptions) workers() int {
+	if o.Workers <= 0 {
+		return runtime.NumCPU()
+	}
+	return o.Workers
+}
+
+// executePartitions executes the partitions by the workers and calls fn for each row.
+// When fn or a partition fails, the rest of the partitions are cancelled and the first error is returned.
+func executePartitions(ctx context.Context, tx *spanner.BatchReadOnlyTransaction, partitions []*spanner.Partition, workers int, fn func(*spanner.Row) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	ch := make(chan *spanner.Partition)
+	for i := 0; i < workers && i < len(partitions); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range ch {
+				if err := executePartition(ctx, tx, p, fn); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+
+send:
+	for _, p := range partitions {
+		select {
+		case ch <- p:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(ch)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return errors.WithStack(ctx.Err())
+}
+
+func executePartition(ctx context.Context, tx *spanner.BatchReadOnlyTransaction, p *spanner.Partition, fn func(*spanner.Row) error) error {
+	rows := tx.Execute(ctx, p)
+	defer rows.Stop()
+	for {
+		row, err := rows.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+}
+
+// collectRows returns the function which maps the row by decode and appends it to the passed pointer of slice.
+// It's safe to be called concurrently.
+func collectRows(target any, decode rowDecoder) func(*spanner.Row) error {
+	slice := reflect.ValueOf(target).Elem()
+	innerType := slice.Type().Elem()
+	var mu sync.Mutex
+	return func(row *spanner.Row) error {
+		e := reflect.New(innerType).Elem()
+		alive, err := decode(row, e.Addr().Interface())
+		if err != nil || !alive {
+			return errors.WithStack(err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		slice.Set(reflect.Append(slice, e))
+		return nil
+	}
+}
+
+func eachRowFunc[T any](decode rowDecoder, fn func(*T) error) func(*spanner.Row) error {
+	return func(row *spanner.Row) error {
+		t := new(T)
+		alive, err := decode(row, t)
+		if err != nil || !alive {
+			return errors.WithStack(err)
+		}
+		return fn(t)
+	}
+}
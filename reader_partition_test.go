@@ -0,0 +1,65 @@
+package spnr
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionOptions_workers(t *testing.T) {
+	assert.Equal(t, runtime.NumCPU(), PartitionOptions{}.workers())
+	assert.Equal(t, 3, PartitionOptions{Workers: 3}.workers())
+}
+
+func TestReader_PartitionQueryNotBatch(t *testing.T) {
+	var records []Test
+	err := testRepository.Reader(context.Background(), nil).PartitionQuery("select * from Test", nil, PartitionOptions{}, &records)
+	assert.Equal(t, ErrNotBatchTransaction, err)
+}
+
+func TestPartitionQuery(t *testing.T) {
+	ctx := context.Background()
+	_, err := testRepository.ApplyInsertOrUpdate(ctx, dataClient, &([]*Test{testRecord3, testRecord4}))
+	assert.Nil(t, err)
+
+	tx, err := dataClient.BatchReadOnlyTransaction(ctx, spanner.StrongRead())
+	assert.Nil(t, err)
+	defer tx.Close()
+
+	var records []Test
+	err = testRepository.Reader(ctx, tx).PartitionQuery("select * from Test", nil, PartitionOptions{Workers: 2}, &records)
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+
+	store := NewMutationStore[Test]("Test")
+	found, err := store.PartitionRead(ctx, tx, spanner.AllKeys(), PartitionOptions{Workers: 2})
+	assert.Nil(t, err)
+	assert.Len(t, found, 2)
+
+	var (
+		mu      sync.Mutex
+		strings []string
+	)
+	err = store.PartitionQueryEach(ctx, tx, "select * from Test", nil, PartitionOptions{}, func(r *Test) error {
+		mu.Lock()
+		defer mu.Unlock()
+		strings = append(strings, r.String)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{testRecord3.String, testRecord4.String}, strings)
+
+	errStop := errors.New("stop")
+	err = store.PartitionReadEach(ctx, tx, spanner.AllKeys(), PartitionOptions{}, func(*Test) error {
+		return errStop
+	})
+	assert.Equal(t, errStop, err)
+
+	_, err = testRepository.ApplyDelete(ctx, dataClient, &([]*Test{testRecord3, testRecord4}))
+	assert.Nil(t, err)
+}
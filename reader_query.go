@@ -3,7 +3,6 @@ package spnr
 import (
 	"reflect"
 
-	"cloud.google.com/go/spanner"
 	"github.com/pkg/errors"
 	"google.golang.org/api/iterator"
 )
@@ -23,9 +22,12 @@ func (r *Reader) QueryOne(sql string, params map[string]any, target any) error {
 	if err := validateStructType(target); err != nil {
 		return err
 	}
+	if err := validateTags(target, r.strict); err != nil {
+		return err
+	}
 	r.logf(readLogTemplate, "sql:"+sql, params)
 
-	iter := r.tx.Query(r.ctx, spanner.Statement{SQL: sql, Params: params})
+	iter := r.query(sql, params)
 	defer iter.Stop()
 
 	row, err := iter.Next()
@@ -36,7 +38,7 @@ func (r *Reader) QueryOne(sql string, params map[string]any, target any) error {
 		return errors.WithStack(err)
 	}
 
-	err = row.ToStruct(target)
+	err = decodeRow(row, target)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -54,11 +56,14 @@ func (r *Reader) Query(sql string, params map[string]any, target any) error {
 	if err := validateStructSliceType(target); err != nil {
 		return err
 	}
+	if err := validateTags(target, r.strict); err != nil {
+		return err
+	}
 	r.logf(readLogTemplate, "sql:"+sql, params)
 	slice := reflect.ValueOf(target).Elem()
 	innerType := slice.Type().Elem()
 
-	iter := r.tx.Query(r.ctx, spanner.Statement{SQL: sql, Params: params})
+	iter := r.query(sql, params)
 	defer iter.Stop()
 
 	for {
@@ -70,7 +75,7 @@ func (r *Reader) Query(sql string, params map[string]any, target any) error {
 			return errors.WithStack(err)
 		}
 		e := reflect.New(innerType).Elem()
-		if err := row.ToStruct(e.Addr().Interface()); err != nil {
+		if err := decodeRow(row, e.Addr().Interface()); err != nil {
 			return errors.WithStack(err)
 		}
 		slice.Set(reflect.Append(slice, e))
@@ -92,7 +97,7 @@ Example:
 */
 func (r *Reader) QueryValue(sql string, params map[string]any, target any) error {
 	r.logf(readLogTemplate, "sql:"+sql, params)
-	iter := r.tx.Query(r.ctx, spanner.Statement{SQL: sql, Params: params})
+	iter := r.query(sql, params)
 	defer iter.Stop()
 
 	row, err := iter.Next()
@@ -134,7 +139,7 @@ func (r *Reader) QueryValues(sql string, params map[string]any, target any) erro
 	slice := reflect.ValueOf(target).Elem()
 	innerType := slice.Type().Elem()
 
-	iter := r.tx.Query(r.ctx, spanner.Statement{SQL: sql, Params: params})
+	iter := r.query(sql, params)
 	defer iter.Stop()
 
 	for {
@@ -0,0 +1,66 @@
+//go:build go1.23
+
+package spnr
+
+import (
+	"context"
+	"iter"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+)
+
+// QuerySeq is the iterator version of QueryEach, which can be used with range-over-func.
+//
+//	for singer, err := range spnr.QuerySeq[Singer](reader, sql, params) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// If an error occurs, it's yielded with the zero value of T and the iteration stops.
+func QuerySeq[T any](r *Reader, sql string, params map[string]any) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		yieldEach(yield, QueryEach(r, sql, params, yieldRow(yield)))
+	}
+}
+
+// FindSeq is the iterator version of FindEach.
+// See QuerySeq for the details.
+func FindSeq[T any](r *Reader, keys spanner.KeySet) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		yieldEach(yield, FindEach(r, keys, yieldRow(yield)))
+	}
+}
+
+// QuerySeq is the iterator version of QueryEach.
+// See spnr.QuerySeq for the details.
+func (s *Store[T]) QuerySeq(ctx context.Context, tx Transaction, sql string, params map[string]any) iter.Seq2[T, error] {
+	return QuerySeq[T](s.Reader(ctx, tx), sql, params)
+}
+
+// FindSeq is the iterator version of FindEach.
+// See spnr.QuerySeq for the details.
+func (s *Store[T]) FindSeq(ctx context.Context, tx Transaction, keys spanner.KeySet) iter.Seq2[T, error] {
+	return FindSeq[T](s.Reader(ctx, tx), keys)
+}
+
+// errStopSeq is returned by the callback when the loop body breaks, so it's not yielded.
+var errStopSeq = errors.New("stop iteration")
+
+func yieldRow[T any](yield func(T, error) bool) func(*T) error {
+	return func(t *T) error {
+		if !yield(*t, nil) {
+			return errStopSeq
+		}
+		return nil
+	}
+}
+
+func yieldEach[T any](yield func(T, error) bool, err error) {
+	if err != nil && !errors.Is(err, errStopSeq) {
+		var zero T
+		yield(zero, err)
+	}
+}
@@ -0,0 +1,43 @@
+//go:build go1.23
+
+package spnr
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuerySeq(t *testing.T) {
+	ctx := context.Background()
+	_, err := testRepository.ApplyInsertOrUpdate(ctx, dataClient, &([]*Test{testRecord3, testRecord4}))
+	assert.Nil(t, err)
+
+	var fetched []Test
+	for r, err := range QuerySeq[Test](testRepository.Reader(ctx, dataClient.Single()), "select * from Test order by `String`", nil) {
+		assert.Nil(t, err)
+		fetched = append(fetched, r)
+	}
+	assert.Len(t, fetched, 2)
+	assert.Equal(t, testRecord3.String, fetched[0].String)
+
+	keys := spanner.KeySetFromKeys(spanner.Key{testRecord3.String, testRecord3.Int64}, spanner.Key{testRecord4.String, testRecord4.Int64})
+	var cnt int
+	for _, err := range testMutationStore.FindSeq(ctx, dataClient.Single(), keys) {
+		assert.Nil(t, err)
+		cnt++
+		break
+	}
+	assert.Equal(t, 1, cnt)
+
+	_, err = testRepository.ApplyDelete(ctx, dataClient, &([]*Test{testRecord3, testRecord4}))
+	assert.Nil(t, err)
+}
+
+func TestQuerySeqInvalidType(t *testing.T) {
+	for _, err := range QuerySeq[int64](testRepository.Reader(context.Background(), nil), "select 1", nil) {
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,114 @@
+package spnr
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+)
+
+// ErrReadTimestampUnavailable is returned by Reader.ReadTimestamp when the read timestamp is not known.
+// It happens when no read has been completed yet, or the transaction of Reader is a read-write transaction.
+var ErrReadTimestampUnavailable = errors.New("read timestamp is unavailable")
+
+// StaleTransaction is the Transaction which executes each read in a new single-use read-only transaction with the timestamp bound.
+// Unlike client.Single(), it can be used for multiple reads.
+// Note that each read may be executed at a different timestamp (unless the bound is spanner.ReadTimestamp).
+// It's safe for concurrent use, but Timestamp is only meaningful when the reads are not concurrent.
+type StaleTransaction struct {
+	client *spanner.Client
+	bound  spanner.TimestampBound
+	mu     sync.Mutex
+	last   *spanner.ReadOnlyTransaction
+}
+
+// NewStaleTransaction returns StaleTransaction which reads with the timestamp bound.
+//
+//	singer, err := singerStore.FindOne(ctx, spnr.NewStaleTransaction(client, spanner.MaxStaleness(15*time.Second)), key)
+func NewStaleTransaction(client *spanner.Client, bound spanner.TimestampBound) *StaleTransaction {
+	return &StaleTransaction{client: client, bound: bound}
+}
+
+func (t *StaleTransaction) single() *spanner.ReadOnlyTransaction {
+	tx := t.client.Single().WithTimestampBound(t.bound)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.last = tx
+	return tx
+}
+
+// Read calls Read of a new single-use transaction.
+func (t *StaleTransaction) Read(ctx context.Context, table string, keys spanner.KeySet, columns []string) *spanner.RowIterator {
+	return t.single().Read(ctx, table, keys, columns)
+}
+
+// ReadRow calls ReadRow of a new single-use transaction.
+func (t *StaleTransaction) ReadRow(ctx context.Context, table string, key spanner.Key, columns []string) (*spanner.Row, error) {
+	return t.single().ReadRow(ctx, table, key, columns)
+}
+
+// Query calls Query of a new single-use transaction.
+func (t *StaleTransaction) Query(ctx context.Context, statement spanner.Statement) *spanner.RowIterator {
+	return t.single().Query(ctx, statement)
+}
+
+// ReadWithOptions calls ReadWithOptions of a new single-use transaction.
+func (t *StaleTransaction) ReadWithOptions(ctx context.Context, table string, keys spanner.KeySet, columns []string, opts *spanner.ReadOptions) *spanner.RowIterator {
+	return t.single().ReadWithOptions(ctx, table, keys, columns, opts)
+}
+
+// ReadRowWithOptions calls ReadRowWithOptions of a new single-use transaction.
+func (t *StaleTransaction) ReadRowWithOptions(ctx context.Context, table string, key spanner.Key, columns []string, opts *spanner.ReadOptions) (*spanner.Row, error) {
+	return t.single().ReadRowWithOptions(ctx, table, key, columns, opts)
+}
+
+// QueryWithOptions calls QueryWithOptions of a new single-use transaction.
+func (t *StaleTransaction) QueryWithOptions(ctx context.Context, statement spanner.Statement, opts spanner.QueryOptions) *spanner.RowIterator {
+	return t.single().QueryWithOptions(ctx, statement, opts)
+}
+
+// Timestamp returns the read timestamp of the last started read.
+func (t *StaleTransaction) Timestamp() (time.Time, error) {
+	t.mu.Lock()
+	last := t.last
+	t.mu.Unlock()
+	if last == nil {
+		return time.Time{}, ErrReadTimestampUnavailable
+	}
+	return last.Timestamp()
+}
+
+/*
+ReadTimestamp returns the timestamp at which the last read of Reader was executed.
+Pass it to spanner.ReadTimestamp to read the data at the same timestamp later.
+
+	reader := singerStore.StaleReader(ctx, client, spanner.MaxStaleness(10*time.Second))
+	err := reader.FindOne(key, &singer)
+	ts, err := reader.ReadTimestamp()
+
+It's available only when the transaction is a read-only transaction (e.g. StaleReader, client.Single()).
+*/
+func (r *Reader) ReadTimestamp() (time.Time, error) {
+	tx, ok := r.tx.(interface{ Timestamp() (time.Time, error) })
+	if !ok {
+		return time.Time{}, ErrReadTimestampUnavailable
+	}
+	ts, err := tx.Timestamp()
+	if err != nil {
+		if errors.Is(err, ErrReadTimestampUnavailable) {
+			return ts, err
+		}
+		return ts, errors.Wrap(ErrReadTimestampUnavailable, err.Error())
+	}
+	return ts, nil
+}
+
+// defaultBound returns Options.TimestampBound, or strong read if it's not specified.
+func defaultBound(bound *spanner.TimestampBound) spanner.TimestampBound {
+	if bound == nil {
+		return spanner.StrongRead()
+	}
+	return *bound
+}
@@ -0,0 +1,77 @@
+package spnr
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReader_ReadTimestampUnavailable(t *testing.T) {
+	_, err := (&Reader{}).ReadTimestamp()
+	assert.ErrorIs(t, err, ErrReadTimestampUnavailable)
+
+	_, err = (&Reader{tx: NewStaleTransaction(nil, spanner.StrongRead())}).ReadTimestamp()
+	assert.ErrorIs(t, err, ErrReadTimestampUnavailable)
+}
+
+func TestDefaultBound(t *testing.T) {
+	assert.Equal(t, spanner.StrongRead().String(), defaultBound(nil).String())
+	bound := spanner.ExactStaleness(10 * time.Second)
+	assert.Equal(t, bound.String(), defaultBound(&bound).String())
+}
+
+func TestStaleReader(t *testing.T) {
+	ctx := context.Background()
+	_, err := testRepository.ApplyInsertOrUpdate(ctx, dataClient, testRecord3)
+	assert.Nil(t, err)
+
+	// the reader can be used for multiple reads
+	reader := testRepository.StaleReader(ctx, dataClient, spanner.MinReadTimestamp(time.Now()))
+	var record Test
+	err = reader.FindOne(spanner.Key{testRecord3.String, testRecord3.Int64}, &record)
+	assert.Nil(t, err)
+	assert.Equal(t, testRecord3.String, record.String)
+
+	ts, err := reader.ReadTimestamp()
+	assert.Nil(t, err)
+	assert.False(t, ts.IsZero())
+
+	var records []Test
+	err = testRepository.StaleReader(ctx, dataClient, spanner.ReadTimestamp(ts)).FindAll(spanner.Key{testRecord3.String, testRecord3.Int64}, &records)
+	assert.Nil(t, err)
+	assert.Len(t, records, 1)
+
+	bound := spanner.MaxStaleness(10 * time.Second)
+	store := NewMutationStoreWithOptions[Test]("Test", &Options{TimestampBound: &bound})
+	found, err := store.FindOne(ctx, NewStaleTransaction(dataClient, bound), spanner.Key{testRecord3.String, testRecord3.Int64})
+	assert.Nil(t, err)
+	assert.Equal(t, testRecord3.String, found.String)
+
+	// StaleTransaction can be shared by concurrent reads
+	tx := NewStaleTransaction(dataClient, bound)
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := store.FindOne(ctx, tx, spanner.Key{testRecord3.String, testRecord3.Int64})
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+	_, err = tx.Timestamp()
+	assert.Nil(t, err)
+
+	reader = store.SingleReader(ctx, dataClient)
+	err = reader.FindOne(spanner.Key{testRecord3.String, testRecord3.Int64}, &record)
+	assert.Nil(t, err)
+	_, err = reader.ReadTimestamp()
+	assert.Nil(t, err)
+
+	_, err = testRepository.ApplyDelete(ctx, dataClient, testRecord3)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,298 @@
+package spnr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+)
+
+// ErrSoftDeleteDisabled is returned by Restore when Options.SoftDeleteColumn is not specified.
+var ErrSoftDeleteDisabled = errors.New("soft delete column is not specified")
+
+// WithDeleted returns the copy of Reader which doesn't exclude the soft deleted records (see Options.SoftDeleteColumn.)
+func (r *Reader) WithDeleted() *Reader {
+	c := *r
+	c.softDelete = ""
+	return &c
+}
+
+// rowDecoder maps the row into target. It returns false if the record is soft deleted, and the record should be skipped.
+type rowDecoder func(row *spanner.Row, target any) (bool, error)
+
+// decodeAny is the rowDecoder which maps every row (e.g. the rows of the queries.)
+func decodeAny(row *spanner.Row, target any) (bool, error) {
+	return true, decodeRow(row, target)
+}
+
+// readColumns returns the columns to read by the primary keys and the rowDecoder for the rows.
+// If the soft deleted records are excluded, the soft delete column is added to the columns unless it's mapped to the struct,
+// and the decoder skips the records whose soft delete column is not NULL.
+func (r *Reader) readColumns(columns []string) ([]string, rowDecoder) {
+	if r.softDelete == "" {
+		return columns, decodeAny
+	}
+	n := len(columns)
+	idx := -1
+	for i, c := range columns {
+		if strings.EqualFold(c, r.softDelete) {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		columns = append(columns[:n:n], r.softDelete)
+		idx = n
+	}
+	return columns, func(row *spanner.Row, target any) (bool, error) {
+		return decodeAlive(row, idx, n, target)
+	}
+}
+
+// decodeAlive maps the first n columns of the row into target unless the record is soft deleted.
+// It returns false if the soft delete column at idx is not NULL.
+func decodeAlive(row *spanner.Row, idx int, n int, target any) (bool, error) {
+	values := make([]spanner.GenericColumnValue, row.Size())
+	for i := range values {
+		if err := row.Column(i, &values[i]); err != nil {
+			return false, errors.WithStack(err)
+		}
+	}
+	if !isNull(values[idx]) {
+		return false, nil
+	}
+	return true, decodeStruct(row.ColumnNames()[:n], values[:n], reflect.ValueOf(target).Elem())
+}
+
+// valueColumns returns the columns to read by GetColumn and GetColumnAll, and the index of the soft delete column (-1 if it's not read.)
+func (r *Reader) valueColumns(column string) ([]string, int) {
+	if r.softDelete == "" {
+		return []string{column}, -1
+	}
+	if strings.EqualFold(column, r.softDelete) {
+		return []string{column}, 0
+	}
+	return []string{column, r.softDelete}, 1
+}
+
+// isSoftDeleted reports whether the soft delete column at idx of the row is not NULL.
+func isSoftDeleted(row *spanner.Row, idx int) (bool, error) {
+	if idx < 0 {
+		return false, nil
+	}
+	var gcv spanner.GenericColumnValue
+	if err := row.Column(idx, &gcv); err != nil {
+		return false, errors.WithStack(err)
+	}
+	return !isNull(gcv), nil
+}
+
+// aliveCond returns the condition to exclude the soft deleted records from the queries built by spnr (e.g. Page), or empty string.
+func (r *Reader) aliveCond() string {
+	if r.softDelete == "" {
+		return ""
+	}
+	return quote(r.softDelete) + " IS NULL"
+}
+
+// Restore clears the soft delete column of the records using mutation API, so that they are read again.
+// You can pass either a struct or a slice of structs.
+// Like DML.Restore, the records which don't exist or are not soft deleted are skipped, so the soft delete column is read in the transaction first.
+// It returns ErrSoftDeleteDisabled if Options.SoftDeleteColumn is not specified.
+func (m *Mutation) Restore(tx *spanner.ReadWriteTransaction, target any) error {
+	return m.RestoreWithContext(context.Background(), tx, target)
+}
+
+// RestoreWithContext is basically same as Restore, but the soft delete column is read with the passed context.
+func (m *Mutation) RestoreWithContext(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) error {
+	isStruct, err := m.validate(target)
+	if err != nil {
+		return err
+	}
+	if m.softDelete == "" {
+		return ErrSoftDeleteDisabled
+	}
+	if isStruct {
+		return m.bufferSoftDelete(ctx, tx, []any{target}, false)
+	}
+	return m.bufferSoftDelete(ctx, tx, toStructSlice(target), false)
+}
+
+// ApplyRestore is basically same as Restore, but it doesn't require transaction.
+// It runs a read-write transaction to read the soft delete column.
+func (m *Mutation) ApplyRestore(ctx context.Context, client *spanner.Client, target any) (time.Time, error) {
+	isStruct, err := m.validate(target)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if m.softDelete == "" {
+		return time.Time{}, ErrSoftDeleteDisabled
+	}
+	if isStruct {
+		return m.applySoftDelete(ctx, client, []any{target}, false)
+	}
+	return m.applySoftDelete(ctx, client, toStructSlice(target), false)
+}
+
+// HardDelete deletes the records physically even if Options.SoftDeleteColumn is specified.
+// See Delete for the details.
+func (m *Mutation) HardDelete(tx *spanner.ReadWriteTransaction, target any) error {
+	c := *m
+	c.softDelete = ""
+	return c.Delete(tx, target)
+}
+
+// ApplyHardDelete is basically same as HardDelete, but it doesn't require transaction.
+func (m *Mutation) ApplyHardDelete(ctx context.Context, client *spanner.Client, target any) (time.Time, error) {
+	c := *m
+	c.softDelete = ""
+	return c.ApplyDelete(ctx, client, target)
+}
+
+// bufferSoftDelete buffers the mutations which set the commit timestamp to the soft delete column (or clear it if deleted is false.)
+// Like the DML statements, the records which don't exist or are already in the state are skipped.
+func (m *Mutation) bufferSoftDelete(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []any, deleted bool) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	tp := reflect.TypeOf(targets[0]).Elem()
+	columns, keys := keyColumns(tp, m.softDelete, targets)
+	current, err := m.readLastColumn(ctx, tx, tp, columns, keys)
+	if err != nil {
+		return err
+	}
+	targets = selectSoftDeleteTargets(targets, current, deleted)
+	if len(targets) == 0 {
+		return nil
+	}
+	var value any = spanner.NullTime{}
+	if deleted {
+		value = spanner.CommitTimestamp
+	}
+	return errors.WithStack(tx.BufferWrite(m.buildSoftDelete(targets, value)))
+}
+
+// applySoftDelete runs a read-write transaction to buffer the mutations by bufferSoftDelete.
+func (m *Mutation) applySoftDelete(ctx context.Context, client *spanner.Client, targets []any, deleted bool) (time.Time, error) {
+	resp, err := client.ReadWriteTransactionWithOptions(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		return m.bufferSoftDelete(ctx, tx, targets, deleted)
+	}, m.opts.transactionOptions())
+	return resp.CommitTs, errors.WithStack(err)
+}
+
+// selectSoftDeleteTargets returns the targets to write, whose records exist in current and are not in the state yet.
+// current is the soft delete column of the records by the string of the primary keys.
+func selectSoftDeleteTargets(targets []any, current map[string]spanner.GenericColumnValue, deleted bool) []any {
+	var selected []any
+	for _, target := range targets {
+		gcv, ok := current[toKey(reflect.ValueOf(target)).String()]
+		if ok && isNull(gcv) == deleted {
+			selected = append(selected, target)
+		}
+	}
+	return selected
+}
+
+// buildSoftDelete builds the update mutations which write the value to the soft delete column.
+func (m *Mutation) buildSoftDelete(targets []any, value any) []*spanner.Mutation {
+	var ms []*spanner.Mutation
+	for _, target := range targets {
+		val := reflect.ValueOf(target)
+		var columns []string
+		for _, f := range getStructInfo(val.Elem().Type()).pks {
+			columns = append(columns, f.name)
+		}
+		columns = append(columns, m.softDelete)
+		values := append([]any(toKey(val)), value)
+		m.logf("Update %s, columns=%+v, values=%+v", m.table, columns, values)
+		ms = append(ms, spanner.Update(m.table, columns, values))
+	}
+	return ms
+}
+
+// Restore clears the soft delete column of the records, so that they are read again.
+// You can pass either a struct or a slice of structs.
+// The records which are not soft deleted are not updated and not counted in rowCount.
+// It returns ErrSoftDeleteDisabled if Options.SoftDeleteColumn is not specified.
+func (d *DML) Restore(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) (rowCount int64, err error) {
+	isStruct, err := d.validate(target)
+	if err != nil {
+		return 0, err
+	}
+	if d.softDelete == "" {
+		return 0, ErrSoftDeleteDisabled
+	}
+	if isStruct {
+		rowCount, err = d.update(ctx, tx, d.buildSoftDeleteStmt(target, false))
+		return rowCount, errors.WithStack(err)
+	}
+	rowCounts, err := d.batchUpdate(ctx, tx, d.buildSoftDeleteStmts(toStructSlice(target), false))
+	return sum(rowCounts), err
+}
+
+// HardDelete deletes the records physically even if Options.SoftDeleteColumn is specified.
+// See Delete for the details.
+func (d *DML) HardDelete(ctx context.Context, tx *spanner.ReadWriteTransaction, target any) (rowCount int64, err error) {
+	c := *d
+	c.softDelete = ""
+	return c.Delete(ctx, tx, target)
+}
+
+func (d *DML) buildSoftDeleteStmts(targets []any, deleted bool) []spanner.Statement {
+	stmts := make([]spanner.Statement, 0, len(targets))
+	for _, target := range targets {
+		stmts = append(stmts, *d.buildSoftDeleteStmt(target, deleted))
+	}
+	return stmts
+}
+
+// buildSoftDeleteStmt builds the statement which sets the commit timestamp to the soft delete column (or clears it if deleted is false.)
+// The records already in the state are not updated.
+func (d *DML) buildSoftDeleteStmt(target any, deleted bool) *spanner.Statement {
+	whereClause, params := buildWherePK(toFields(target))
+	sql := fmt.Sprintf("UPDATE %s SET %s WHERE %s AND %s",
+		d.getTableName(),
+		d.softDeleteSet(deleted),
+		whereClause,
+		d.softDeleteCond(deleted),
+	)
+	d.log(sql, params)
+	return &spanner.Statement{
+		SQL:    sql,
+		Params: params,
+	}
+}
+
+// buildSoftDeleteAllStmt is the slice version of buildSoftDeleteStmt, which builds one statement for all the records.
+func (d *DML) buildSoftDeleteAllStmt(target any) *spanner.Statement {
+	whereClause, params := buildWherePKs(target)
+	sql := fmt.Sprintf("UPDATE %s SET %s WHERE (%s) AND %s",
+		d.getTableName(),
+		d.softDeleteSet(true),
+		whereClause,
+		d.softDeleteCond(true),
+	)
+	d.log(sql, params)
+	return &spanner.Statement{
+		SQL:    sql,
+		Params: params,
+	}
+}
+
+func (d *DML) softDeleteSet(deleted bool) string {
+	if deleted {
+		return quote(d.softDelete) + "=" + pendingCommitTimestamp
+	}
+	return quote(d.softDelete) + "=NULL"
+}
+
+func (d *DML) softDeleteCond(deleted bool) string {
+	if deleted {
+		return quote(d.softDelete) + " IS NULL"
+	}
+	return quote(d.softDelete) + " IS NOT NULL"
+}
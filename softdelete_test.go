@@ -0,0 +1,133 @@
+package spnr
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSoftDelete struct {
+	ID   string `spanner:"ID" pk:"1"`
+	Name string `spanner:"Name"`
+}
+
+func TestDML_buildSoftDeleteStmt(t *testing.T) {
+	dml := NewDMLWithOptions("Singers", &Options{SoftDeleteColumn: "DeletedAt"})
+	stmt := dml.buildDeleteStmt(&testSoftDelete{ID: "a"})
+	assert.Equal(t, "UPDATE `Singers` SET `DeletedAt`=PENDING_COMMIT_TIMESTAMP() WHERE `ID`=@w_ID AND `DeletedAt` IS NULL", stmt.SQL)
+	assert.Equal(t, map[string]any{"w_ID": "a"}, stmt.Params)
+
+	stmt = dml.buildDeleteAllStmt(&[]testSoftDelete{{ID: "a"}, {ID: "b"}})
+	assert.Equal(t, "UPDATE `Singers` SET `DeletedAt`=PENDING_COMMIT_TIMESTAMP() WHERE ((`ID`=@w_ID_0) OR (`ID`=@w_ID_1)) AND `DeletedAt` IS NULL", stmt.SQL)
+
+	stmt = dml.buildSoftDeleteStmt(&testSoftDelete{ID: "a"}, false)
+	assert.Equal(t, "UPDATE `Singers` SET `DeletedAt`=NULL WHERE `ID`=@w_ID AND `DeletedAt` IS NOT NULL", stmt.SQL)
+
+	hard := NewDML("Singers")
+	assert.Equal(t, "DELETE FROM `Singers` WHERE `ID`=@w_ID", hard.buildDeleteStmt(&testSoftDelete{ID: "a"}).SQL)
+	assert.Equal(t, "DELETE FROM `Singers` WHERE (`ID`=@w_ID_0) OR (`ID`=@w_ID_1)", hard.buildDeleteAllStmt(&[]testSoftDelete{{ID: "a"}, {ID: "b"}}).SQL)
+
+	_, err := hard.Restore(nil, nil, &testSoftDelete{ID: "a"})
+	assert.Equal(t, ErrSoftDeleteDisabled, err)
+}
+
+func TestMutation_buildSoftDelete(t *testing.T) {
+	m := NewMutationWithOptions("Singers", &Options{SoftDeleteColumn: "DeletedAt"})
+	ms := m.buildSoftDelete([]any{&testSoftDelete{ID: "a"}}, spanner.CommitTimestamp)
+	assert.Equal(t, []*spanner.Mutation{spanner.Update("Singers", []string{"ID", "DeletedAt"}, []any{"a", spanner.CommitTimestamp})}, ms)
+
+	ms = m.buildSoftDelete([]any{&testSoftDelete{ID: "a"}}, spanner.NullTime{})
+	assert.Equal(t, []*spanner.Mutation{spanner.Update("Singers", []string{"ID", "DeletedAt"}, []any{"a", spanner.NullTime{}})}, ms)
+
+	assert.Equal(t, ErrSoftDeleteDisabled, NewMutation("Singers").Restore(nil, &testSoftDelete{ID: "a"}))
+}
+
+func TestSelectSoftDeleteTargets(t *testing.T) {
+	alive, deleted, missing := &testSoftDelete{ID: "a"}, &testSoftDelete{ID: "b"}, &testSoftDelete{ID: "c"}
+	current := map[string]spanner.GenericColumnValue{}
+	for id, v := range map[string]any{"a": spanner.NullTime{}, "b": time.Now()} {
+		row, err := spanner.NewRow([]string{"DeletedAt"}, []any{v})
+		assert.Nil(t, err)
+		var gcv spanner.GenericColumnValue
+		assert.Nil(t, row.Column(0, &gcv))
+		current[spanner.Key{id}.String()] = gcv
+	}
+	// like DML, the missing records and the records already in the state are not written
+	targets := []any{alive, deleted, missing}
+	assert.Equal(t, []any{alive}, selectSoftDeleteTargets(targets, current, true))
+	assert.Equal(t, []any{deleted}, selectSoftDeleteTargets(targets, current, false))
+	assert.Nil(t, selectSoftDeleteTargets(targets, map[string]spanner.GenericColumnValue{}, true))
+}
+
+func TestReader_softDelete(t *testing.T) {
+	ctx := context.Background()
+	r := NewMutationWithOptions("Singers", &Options{SoftDeleteColumn: "DeletedAt"}).Reader(ctx, nil)
+	columns, _ := r.readColumns([]string{"ID", "Name"})
+	assert.Equal(t, []string{"ID", "Name", "DeletedAt"}, columns)
+
+	// the column mapped to the struct is matched case-insensitively
+	columns, _ = r.readColumns([]string{"ID", "deletedAt"})
+	assert.Equal(t, []string{"ID", "deletedAt"}, columns)
+
+	columns, _ = r.WithDeleted().readColumns([]string{"ID", "Name"})
+	assert.Equal(t, []string{"ID", "Name"}, columns)
+
+	_, decode := r.readColumns([]string{"ID", "Name"})
+	var s testSoftDelete
+	alive, err := decode(newSoftDeleteRow(t, "a", "b", spanner.NullTime{}), &s)
+	assert.Nil(t, err)
+	assert.True(t, alive)
+	assert.Equal(t, testSoftDelete{ID: "a", Name: "b"}, s)
+
+	alive, err = decode(newSoftDeleteRow(t, "c", "d", time.Now()), &s)
+	assert.Nil(t, err)
+	assert.False(t, alive)
+	assert.Equal(t, "a", s.ID)
+
+	// the partitioned reads and FindEach skip the soft deleted records by the decoder
+	var collected []testSoftDelete
+	collect := collectRows(&collected, decode)
+	assert.Nil(t, collect(newSoftDeleteRow(t, "a", "b", spanner.NullTime{})))
+	assert.Nil(t, collect(newSoftDeleteRow(t, "c", "d", time.Now())))
+	assert.Equal(t, []testSoftDelete{{ID: "a", Name: "b"}}, collected)
+
+	var called []string
+	each := eachRowFunc(decode, func(s *testSoftDelete) error {
+		called = append(called, s.ID)
+		return nil
+	})
+	assert.Nil(t, each(newSoftDeleteRow(t, "a", "b", spanner.NullTime{})))
+	assert.Nil(t, each(newSoftDeleteRow(t, "c", "d", time.Now())))
+	assert.Equal(t, []string{"a"}, called)
+
+	columns, idx := r.valueColumns("Name")
+	assert.Equal(t, []string{"Name", "DeletedAt"}, columns)
+	assert.Equal(t, 1, idx)
+	deleted, err := isSoftDeleted(newSoftDeleteRow(t, "c", "d", time.Now()), 2)
+	assert.Nil(t, err)
+	assert.True(t, deleted)
+	columns, idx = r.WithDeleted().valueColumns("Name")
+	assert.Equal(t, []string{"Name"}, columns)
+	assert.Equal(t, -1, idx)
+
+	// the index must store the soft delete column to be covering
+	si := getStructInfo(reflect.TypeOf(testSoftDelete{}))
+	r.indexes = map[string][]string{"ByName": {"Name"}, "ByNameWithDeletedAt": {"Name", "deletedat"}}
+	assert.False(t, r.isCovering("ByName", si))
+	assert.True(t, r.isCovering("ByNameWithDeletedAt", si))
+	assert.True(t, r.WithDeleted().isCovering("ByName", si))
+
+	store := NewMutationStoreWithOptions[testSoftDelete]("Singers", &Options{SoftDeleteColumn: "DeletedAt"})
+	assert.Equal(t, "DeletedAt", store.Reader(ctx, nil).softDelete)
+	assert.Equal(t, "", store.WithDeleted().Reader(ctx, nil).softDelete)
+}
+
+func newSoftDeleteRow(t *testing.T, id, name string, deletedAt any) *spanner.Row {
+	row, err := spanner.NewRow([]string{"ID", "Name", "DeletedAt"}, []any{id, name, deletedAt})
+	assert.Nil(t, err)
+	return row
+}
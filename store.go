@@ -0,0 +1,116 @@
+package spnr
+
+import (
+	"context"
+
+	"cloud.google.com/go/spanner"
+)
+
+type readerProvider interface {
+	Reader(ctx context.Context, tx Transaction) *Reader
+	StaleReader(ctx context.Context, client *spanner.Client, bound spanner.TimestampBound) *Reader
+	SingleReader(ctx context.Context, client *spanner.Client) *Reader
+	GetTableName() string
+}
+
+// Store offers type-safe read operations for the records mapped to T.
+// Store is embedded in MutationStore and DMLStore, so usually you don't need to initialize it directly.
+type Store[T any] struct {
+	base        readerProvider
+	withDeleted bool
+}
+
+// Reader returns Reader struct to call read operations which are not offered by Store (e.g. GetColumn, QueryValue).
+func (s *Store[T]) Reader(ctx context.Context, tx Transaction) *Reader {
+	return s.reader(s.base.Reader(ctx, tx))
+}
+
+// StaleReader returns Reader which reads with the timestamp bound.
+// See Mutation.StaleReader for the details.
+func (s *Store[T]) StaleReader(ctx context.Context, client *spanner.Client, bound spanner.TimestampBound) *Reader {
+	return s.reader(s.base.StaleReader(ctx, client, bound))
+}
+
+// SingleReader returns Reader which reads with Options.TimestampBound.
+// See Mutation.SingleReader for the details.
+func (s *Store[T]) SingleReader(ctx context.Context, client *spanner.Client) *Reader {
+	return s.reader(s.base.SingleReader(ctx, client))
+}
+
+// WithDeleted returns the copy of Store whose reads don't exclude the soft deleted records (see Options.SoftDeleteColumn.)
+//
+//	singer, err := store.WithDeleted().FindOne(ctx, tx, key)
+func (s *Store[T]) WithDeleted() *Store[T] {
+	return &Store[T]{base: s.base, withDeleted: true}
+}
+
+func (s *Store[T]) reader(r *Reader) *Reader {
+	if s.withDeleted {
+		return r.WithDeleted()
+	}
+	return r
+}
+
+// GetTableName returns table name
+func (s *Store[T]) GetTableName() string {
+	return s.base.GetTableName()
+}
+
+// FindOne fetches a record by specified primary key.
+// If no record is found, this method will return ErrNotFound.
+func (s *Store[T]) FindOne(ctx context.Context, tx Transaction, key spanner.Key) (*T, error) {
+	var t T
+	if err := s.Reader(ctx, tx).FindOne(key, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// FindAll fetches records by specified a set of primary keys.
+func (s *Store[T]) FindAll(ctx context.Context, tx Transaction, keys spanner.KeySet) ([]T, error) {
+	var ts []T
+	if err := s.Reader(ctx, tx).FindAll(keys, &ts); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// QueryOne fetches a record by calling specified query.
+// If no records are found, this method will return ErrNotFound.
+// If multiple records are found, this method will return ErrMoreThanOneRecordFound.
+func (s *Store[T]) QueryOne(ctx context.Context, tx Transaction, sql string, params map[string]any) (*T, error) {
+	var t T
+	if err := s.Reader(ctx, tx).QueryOne(sql, params, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Query fetches records by calling specified query.
+func (s *Store[T]) Query(ctx context.Context, tx Transaction, sql string, params map[string]any) ([]T, error) {
+	var ts []T
+	if err := s.Reader(ctx, tx).Query(sql, params, &ts); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// FindOneByIndex fetches a record by specified key of the secondary index.
+// See Reader.FindOneByIndex for the details.
+func (s *Store[T]) FindOneByIndex(ctx context.Context, tx Transaction, index string, key spanner.Key) (*T, error) {
+	var t T
+	if err := s.Reader(ctx, tx).FindOneByIndex(index, key, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// FindAllByIndex fetches records by specified keys of the secondary index.
+// See Reader.FindAllByIndex for the details.
+func (s *Store[T]) FindAllByIndex(ctx context.Context, tx Transaction, index string, keys spanner.KeySet) ([]T, error) {
+	var ts []T
+	if err := s.Reader(ctx, tx).FindAllByIndex(index, keys, &ts); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
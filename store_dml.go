@@ -0,0 +1,200 @@
+package spnr
+
+import (
+	"context"
+
+	"cloud.google.com/go/spanner"
+)
+
+// DMLStore offers type-safe ORM with DML for the records mapped to T.
+// It also contains type-safe read operations of Store.
+type DMLStore[T any] struct {
+	Store[T]
+	dml *DML
+}
+
+// NewDMLStore initializes type-safe ORM with DML.
+// If you want to use Mutation API, use NewMutationStore() instead.
+func NewDMLStore[T any](tableName string) *DMLStore[T] {
+	return newDMLStore[T](NewDML(tableName))
+}
+
+// NewDMLStoreWithOptions initializes DMLStore with options.
+// Check Options for the available options.
+func NewDMLStoreWithOptions[T any](tableName string, op *Options) *DMLStore[T] {
+	return newDMLStore[T](NewDMLWithOptions(tableName, op))
+}
+
+func newDMLStore[T any](d *DML) *DMLStore[T] {
+	return &DMLStore[T]{Store: Store[T]{base: d}, dml: d}
+}
+
+// Insert build and execute insert statement from the passed struct.
+// See DML.Insert for the details.
+func (s *DMLStore[T]) Insert(ctx context.Context, tx *spanner.ReadWriteTransaction, target *T) (rowCount int64, err error) {
+	return s.dml.Insert(ctx, tx, target)
+}
+
+// InsertAll is the slice version of Insert.
+func (s *DMLStore[T]) InsertAll(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) (rowCount int64, err error) {
+	return s.dml.Insert(ctx, tx, &targets)
+}
+
+// Update build and execute update statement from the passed struct.
+// See DML.Update for the details.
+func (s *DMLStore[T]) Update(ctx context.Context, tx *spanner.ReadWriteTransaction, target *T) (rowCount int64, err error) {
+	return s.dml.Update(ctx, tx, target)
+}
+
+// UpdateAll is the slice version of Update.
+func (s *DMLStore[T]) UpdateAll(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) (rowCount int64, err error) {
+	return s.dml.Update(ctx, tx, &targets)
+}
+
+// UpdateColumns build and execute update statement for the specified columns from the passed struct.
+func (s *DMLStore[T]) UpdateColumns(ctx context.Context, tx *spanner.ReadWriteTransaction, columns []string, target *T) (rowCount int64, err error) {
+	return s.dml.UpdateColumns(ctx, tx, columns, target)
+}
+
+// Delete build and execute delete statement from the passed struct.
+// See DML.Delete for the details.
+func (s *DMLStore[T]) Delete(ctx context.Context, tx *spanner.ReadWriteTransaction, target *T) (rowCount int64, err error) {
+	return s.dml.Delete(ctx, tx, target)
+}
+
+// DeleteAll is the slice version of Delete.
+func (s *DMLStore[T]) DeleteAll(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) (rowCount int64, err error) {
+	return s.dml.Delete(ctx, tx, &targets)
+}
+
+// HardDelete deletes the record physically even if Options.SoftDeleteColumn is specified.
+// See DML.HardDelete for the details.
+func (s *DMLStore[T]) HardDelete(ctx context.Context, tx *spanner.ReadWriteTransaction, target *T) (rowCount int64, err error) {
+	return s.dml.HardDelete(ctx, tx, target)
+}
+
+// HardDeleteAll is the slice version of HardDelete.
+func (s *DMLStore[T]) HardDeleteAll(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) (rowCount int64, err error) {
+	return s.dml.HardDelete(ctx, tx, &targets)
+}
+
+// Restore clears the soft delete column of the record.
+// See DML.Restore for the details.
+func (s *DMLStore[T]) Restore(ctx context.Context, tx *spanner.ReadWriteTransaction, target *T) (rowCount int64, err error) {
+	return s.dml.Restore(ctx, tx, target)
+}
+
+// RestoreAll is the slice version of Restore.
+func (s *DMLStore[T]) RestoreAll(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) (rowCount int64, err error) {
+	return s.dml.Restore(ctx, tx, &targets)
+}
+
+// InsertAllChunked is the chunked version of InsertAll.
+// See DML.InsertChunked for the details.
+func (s *DMLStore[T]) InsertAllChunked(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) ([]ChunkResult, error) {
+	return s.dml.InsertChunked(ctx, tx, &targets)
+}
+
+// ApplyInsertAllChunked inserts the records in chunks, and each chunk is executed in a separate transaction.
+// See DML.ApplyInsertChunked for the details.
+func (s *DMLStore[T]) ApplyInsertAllChunked(ctx context.Context, client *spanner.Client, targets []T) ([]ChunkResult, error) {
+	return s.dml.ApplyInsertChunked(ctx, client, &targets)
+}
+
+// UpdateAllChunked is the chunked version of UpdateAll.
+// See DML.UpdateChunked for the details.
+func (s *DMLStore[T]) UpdateAllChunked(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) ([]ChunkResult, error) {
+	return s.dml.UpdateChunked(ctx, tx, &targets)
+}
+
+// ApplyUpdateAllChunked updates the records in chunks, and each chunk is executed in a separate transaction.
+// See DML.ApplyUpdateChunked for the details.
+func (s *DMLStore[T]) ApplyUpdateAllChunked(ctx context.Context, client *spanner.Client, targets []T) ([]ChunkResult, error) {
+	return s.dml.ApplyUpdateChunked(ctx, client, &targets)
+}
+
+// DeleteAllChunked is the chunked version of DeleteAll.
+// See DML.DeleteChunked for the details.
+func (s *DMLStore[T]) DeleteAllChunked(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) ([]ChunkResult, error) {
+	return s.dml.DeleteChunked(ctx, tx, &targets)
+}
+
+// ApplyDeleteAllChunked deletes the records in chunks, and each chunk is executed in a separate transaction.
+// See DML.ApplyDeleteChunked for the details.
+func (s *DMLStore[T]) ApplyDeleteAllChunked(ctx context.Context, client *spanner.Client, targets []T) ([]ChunkResult, error) {
+	return s.dml.ApplyDeleteChunked(ctx, client, &targets)
+}
+
+// UpdateAllBatch is basically same as UpdateAll, but it returns the row count of each statement.
+// See DML.UpdateBatch for the details.
+func (s *DMLStore[T]) UpdateAllBatch(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) (rowCounts []int64, err error) {
+	return s.dml.UpdateBatch(ctx, tx, &targets)
+}
+
+// UpdateColumnsAll is the slice version of UpdateColumns.
+func (s *DMLStore[T]) UpdateColumnsAll(ctx context.Context, tx *spanner.ReadWriteTransaction, columns []string, targets []T) (rowCount int64, err error) {
+	return s.dml.UpdateColumns(ctx, tx, columns, &targets)
+}
+
+// DeleteAllBatch is basically same as DeleteAll, but it returns the row count of each statement.
+// See DML.DeleteBatch for the details.
+func (s *DMLStore[T]) DeleteAllBatch(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) (rowCounts []int64, err error) {
+	return s.dml.DeleteBatch(ctx, tx, &targets)
+}
+
+// PartitionedUpdate executes update statement for the whole table as partitioned DML.
+// See DML.PartitionedUpdate for the details.
+func (s *DMLStore[T]) PartitionedUpdate(ctx context.Context, client *spanner.Client, set string, where string, params map[string]any) (rowCount int64, err error) {
+	return s.dml.PartitionedUpdate(ctx, client, set, where, params)
+}
+
+// PartitionedDelete executes delete statement for the whole table as partitioned DML.
+// See DML.PartitionedDelete for the details.
+func (s *DMLStore[T]) PartitionedDelete(ctx context.Context, client *spanner.Client, where string, params map[string]any) (rowCount int64, err error) {
+	return s.dml.PartitionedDelete(ctx, client, where, params)
+}
+
+// PartitionedHardDelete deletes the records physically as partitioned DML even if Options.SoftDeleteColumn is specified.
+// See DML.PartitionedHardDelete for the details.
+func (s *DMLStore[T]) PartitionedHardDelete(ctx context.Context, client *spanner.Client, where string, params map[string]any) (rowCount int64, err error) {
+	return s.dml.PartitionedHardDelete(ctx, client, where, params)
+}
+
+// InsertReturning inserts the record and reads back the stored values into it.
+// See DML.InsertReturning for the details.
+func (s *DMLStore[T]) InsertReturning(ctx context.Context, tx *spanner.ReadWriteTransaction, target *T) (rowCount int64, err error) {
+	return s.dml.InsertReturning(ctx, tx, target)
+}
+
+// InsertAllReturning is the slice version of InsertReturning.
+func (s *DMLStore[T]) InsertAllReturning(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) (rowCount int64, err error) {
+	return s.dml.InsertReturning(ctx, tx, &targets)
+}
+
+// UpdateReturning updates the record and reads back the stored values into it.
+// See DML.UpdateReturning for the details.
+func (s *DMLStore[T]) UpdateReturning(ctx context.Context, tx *spanner.ReadWriteTransaction, target *T) (rowCount int64, err error) {
+	return s.dml.UpdateReturning(ctx, tx, target)
+}
+
+// UpdateAllReturning is the slice version of UpdateReturning.
+func (s *DMLStore[T]) UpdateAllReturning(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) (rowCount int64, err error) {
+	return s.dml.UpdateReturning(ctx, tx, &targets)
+}
+
+// DeleteReturning deletes the record and reads back the deleted values into it.
+// See DML.DeleteReturning for the details.
+func (s *DMLStore[T]) DeleteReturning(ctx context.Context, tx *spanner.ReadWriteTransaction, target *T) (rowCount int64, err error) {
+	return s.dml.DeleteReturning(ctx, tx, target)
+}
+
+// DeleteAllReturning is the slice version of DeleteReturning.
+func (s *DMLStore[T]) DeleteAllReturning(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) (rowCount int64, err error) {
+	return s.dml.DeleteReturning(ctx, tx, &targets)
+}
+
+// With returns the copy of DMLStore which sends the requests with the passed options.
+// See DML.With for the details.
+func (s *DMLStore[T]) With(opts ...Option) *DMLStore[T] {
+	return newDMLStore[T](s.dml.With(opts...))
+}
@@ -0,0 +1,259 @@
+package spnr
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/spanner"
+)
+
+// MutationStore offers type-safe ORM with Mutation API for the records mapped to T.
+// It also contains type-safe read operations of Store.
+type MutationStore[T any] struct {
+	Store[T]
+	mutation *Mutation
+}
+
+// NewMutationStore initializes type-safe ORM with Mutation API.
+// If you want to use DML, use NewDMLStore() instead.
+func NewMutationStore[T any](tableName string) *MutationStore[T] {
+	return newMutationStore[T](NewMutation(tableName))
+}
+
+// NewMutationStoreWithOptions initializes MutationStore with options.
+// Check Options for the available options.
+func NewMutationStoreWithOptions[T any](tableName string, op *Options) *MutationStore[T] {
+	return newMutationStore[T](NewMutationWithOptions(tableName, op))
+}
+
+func newMutationStore[T any](m *Mutation) *MutationStore[T] {
+	return &MutationStore[T]{Store: Store[T]{base: m}, mutation: m}
+}
+
+// Insert build and execute insert operation using mutation API.
+// See Mutation.Insert for the details.
+func (s *MutationStore[T]) Insert(tx *spanner.ReadWriteTransaction, target *T) error {
+	return s.mutation.Insert(tx, target)
+}
+
+// InsertAll is the slice version of Insert.
+func (s *MutationStore[T]) InsertAll(tx *spanner.ReadWriteTransaction, targets []T) error {
+	return s.mutation.Insert(tx, &targets)
+}
+
+// ApplyInsert is basically same as Insert, but it doesn't require transaction.
+// If the record already exists, this method will return the error which matches ErrAlreadyExists by errors.Is.
+func (s *MutationStore[T]) ApplyInsert(ctx context.Context, client *spanner.Client, target *T) (time.Time, error) {
+	return s.mutation.ApplyInsert(ctx, client, target)
+}
+
+// ApplyInsertAll is the slice version of ApplyInsert.
+func (s *MutationStore[T]) ApplyInsertAll(ctx context.Context, client *spanner.Client, targets []T) (time.Time, error) {
+	return s.mutation.ApplyInsert(ctx, client, &targets)
+}
+
+// Replace build and execute replace operation using mutation API.
+// See Mutation.Replace for the details.
+func (s *MutationStore[T]) Replace(tx *spanner.ReadWriteTransaction, target *T) error {
+	return s.mutation.Replace(tx, target)
+}
+
+// ReplaceAll is the slice version of Replace.
+func (s *MutationStore[T]) ReplaceAll(tx *spanner.ReadWriteTransaction, targets []T) error {
+	return s.mutation.Replace(tx, &targets)
+}
+
+// ApplyReplace is basically same as Replace, but it doesn't require transaction.
+func (s *MutationStore[T]) ApplyReplace(ctx context.Context, client *spanner.Client, target *T) (time.Time, error) {
+	return s.mutation.ApplyReplace(ctx, client, target)
+}
+
+// ApplyReplaceAll is the slice version of ApplyReplace.
+func (s *MutationStore[T]) ApplyReplaceAll(ctx context.Context, client *spanner.Client, targets []T) (time.Time, error) {
+	return s.mutation.ApplyReplace(ctx, client, &targets)
+}
+
+// InsertOrUpdate build and execute insert_or_update operation using mutation API.
+// See Mutation.InsertOrUpdate for the details.
+func (s *MutationStore[T]) InsertOrUpdate(tx *spanner.ReadWriteTransaction, target *T) error {
+	return s.mutation.InsertOrUpdate(tx, target)
+}
+
+// InsertOrUpdateAll is the slice version of InsertOrUpdate.
+func (s *MutationStore[T]) InsertOrUpdateAll(tx *spanner.ReadWriteTransaction, targets []T) error {
+	return s.mutation.InsertOrUpdate(tx, &targets)
+}
+
+// InsertOrUpdateColumns build and execute insert_or_update operation for specified columns using mutation API.
+func (s *MutationStore[T]) InsertOrUpdateColumns(tx *spanner.ReadWriteTransaction, columns []string, target *T) error {
+	return s.mutation.InsertOrUpdateColumns(tx, columns, target)
+}
+
+// ApplyInsertOrUpdate is basically same as InsertOrUpdate, but it doesn't require transaction.
+func (s *MutationStore[T]) ApplyInsertOrUpdate(ctx context.Context, client *spanner.Client, target *T) (time.Time, error) {
+	return s.mutation.ApplyInsertOrUpdate(ctx, client, target)
+}
+
+// ApplyInsertOrUpdateAll is the slice version of ApplyInsertOrUpdate.
+func (s *MutationStore[T]) ApplyInsertOrUpdateAll(ctx context.Context, client *spanner.Client, targets []T) (time.Time, error) {
+	return s.mutation.ApplyInsertOrUpdate(ctx, client, &targets)
+}
+
+// Update build and execute update operation using mutation API.
+// See Mutation.Update for the details.
+func (s *MutationStore[T]) Update(tx *spanner.ReadWriteTransaction, target *T) error {
+	return s.mutation.Update(tx, target)
+}
+
+// UpdateAll is the slice version of Update.
+func (s *MutationStore[T]) UpdateAll(tx *spanner.ReadWriteTransaction, targets []T) error {
+	return s.mutation.Update(tx, &targets)
+}
+
+// UpdateWithContext is basically same as Update, but the versions are read with the passed context.
+// See Mutation.UpdateWithContext for the details.
+func (s *MutationStore[T]) UpdateWithContext(ctx context.Context, tx *spanner.ReadWriteTransaction, target *T) error {
+	return s.mutation.UpdateWithContext(ctx, tx, target)
+}
+
+// UpdateAllWithContext is the slice version of UpdateWithContext.
+func (s *MutationStore[T]) UpdateAllWithContext(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) error {
+	return s.mutation.UpdateWithContext(ctx, tx, &targets)
+}
+
+// UpdateColumns build and execute update operation for specified columns using mutation API.
+func (s *MutationStore[T]) UpdateColumns(tx *spanner.ReadWriteTransaction, columns []string, target *T) error {
+	return s.mutation.UpdateColumns(tx, columns, target)
+}
+
+// UpdateColumnsWithContext is basically same as UpdateColumns, but the versions are read with the passed context.
+func (s *MutationStore[T]) UpdateColumnsWithContext(ctx context.Context, tx *spanner.ReadWriteTransaction, columns []string, target *T) error {
+	return s.mutation.UpdateColumnsWithContext(ctx, tx, columns, target)
+}
+
+// ApplyUpdate is basically same as Update, but it doesn't require transaction.
+func (s *MutationStore[T]) ApplyUpdate(ctx context.Context, client *spanner.Client, target *T) (time.Time, error) {
+	return s.mutation.ApplyUpdate(ctx, client, target)
+}
+
+// ApplyUpdateAll is the slice version of ApplyUpdate.
+func (s *MutationStore[T]) ApplyUpdateAll(ctx context.Context, client *spanner.Client, targets []T) (time.Time, error) {
+	return s.mutation.ApplyUpdate(ctx, client, &targets)
+}
+
+// Delete build and execute delete operation using mutation API.
+// See Mutation.Delete for the details.
+func (s *MutationStore[T]) Delete(tx *spanner.ReadWriteTransaction, target *T) error {
+	return s.mutation.Delete(tx, target)
+}
+
+// DeleteAll is the slice version of Delete.
+func (s *MutationStore[T]) DeleteAll(tx *spanner.ReadWriteTransaction, targets []T) error {
+	return s.mutation.Delete(tx, &targets)
+}
+
+// DeleteWithContext is basically same as Delete, but the soft delete column is read with the passed context.
+// See Mutation.DeleteWithContext for the details.
+func (s *MutationStore[T]) DeleteWithContext(ctx context.Context, tx *spanner.ReadWriteTransaction, target *T) error {
+	return s.mutation.DeleteWithContext(ctx, tx, target)
+}
+
+// DeleteAllWithContext is the slice version of DeleteWithContext.
+func (s *MutationStore[T]) DeleteAllWithContext(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) error {
+	return s.mutation.DeleteWithContext(ctx, tx, &targets)
+}
+
+// ApplyDelete is basically same as Delete, but it doesn't require transaction.
+func (s *MutationStore[T]) ApplyDelete(ctx context.Context, client *spanner.Client, target *T) (time.Time, error) {
+	return s.mutation.ApplyDelete(ctx, client, target)
+}
+
+// ApplyDeleteAll is the slice version of ApplyDelete.
+func (s *MutationStore[T]) ApplyDeleteAll(ctx context.Context, client *spanner.Client, targets []T) (time.Time, error) {
+	return s.mutation.ApplyDelete(ctx, client, &targets)
+}
+
+// HardDelete deletes the record physically even if Options.SoftDeleteColumn is specified.
+// See Mutation.HardDelete for the details.
+func (s *MutationStore[T]) HardDelete(tx *spanner.ReadWriteTransaction, target *T) error {
+	return s.mutation.HardDelete(tx, target)
+}
+
+// HardDeleteAll is the slice version of HardDelete.
+func (s *MutationStore[T]) HardDeleteAll(tx *spanner.ReadWriteTransaction, targets []T) error {
+	return s.mutation.HardDelete(tx, &targets)
+}
+
+// ApplyHardDelete is basically same as HardDelete, but it doesn't require transaction.
+func (s *MutationStore[T]) ApplyHardDelete(ctx context.Context, client *spanner.Client, target *T) (time.Time, error) {
+	return s.mutation.ApplyHardDelete(ctx, client, target)
+}
+
+// ApplyHardDeleteAll is the slice version of ApplyHardDelete.
+func (s *MutationStore[T]) ApplyHardDeleteAll(ctx context.Context, client *spanner.Client, targets []T) (time.Time, error) {
+	return s.mutation.ApplyHardDelete(ctx, client, &targets)
+}
+
+// Restore clears the soft delete column of the record.
+// See Mutation.Restore for the details.
+func (s *MutationStore[T]) Restore(tx *spanner.ReadWriteTransaction, target *T) error {
+	return s.mutation.Restore(tx, target)
+}
+
+// RestoreAll is the slice version of Restore.
+func (s *MutationStore[T]) RestoreAll(tx *spanner.ReadWriteTransaction, targets []T) error {
+	return s.mutation.Restore(tx, &targets)
+}
+
+// RestoreWithContext is basically same as Restore, but the soft delete column is read with the passed context.
+// See Mutation.RestoreWithContext for the details.
+func (s *MutationStore[T]) RestoreWithContext(ctx context.Context, tx *spanner.ReadWriteTransaction, target *T) error {
+	return s.mutation.RestoreWithContext(ctx, tx, target)
+}
+
+// RestoreAllWithContext is the slice version of RestoreWithContext.
+func (s *MutationStore[T]) RestoreAllWithContext(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []T) error {
+	return s.mutation.RestoreWithContext(ctx, tx, &targets)
+}
+
+// ApplyRestore is basically same as Restore, but it doesn't require transaction.
+func (s *MutationStore[T]) ApplyRestore(ctx context.Context, client *spanner.Client, target *T) (time.Time, error) {
+	return s.mutation.ApplyRestore(ctx, client, target)
+}
+
+// ApplyRestoreAll is the slice version of ApplyRestore.
+func (s *MutationStore[T]) ApplyRestoreAll(ctx context.Context, client *spanner.Client, targets []T) (time.Time, error) {
+	return s.mutation.ApplyRestore(ctx, client, &targets)
+}
+
+// ApplyInsertAllChunked is the chunked version of ApplyInsertAll.
+// See Mutation.ApplyInsertChunked for the details.
+func (s *MutationStore[T]) ApplyInsertAllChunked(ctx context.Context, client *spanner.Client, targets []T) ([]ChunkResult, error) {
+	return s.mutation.ApplyInsertChunked(ctx, client, &targets)
+}
+
+// ApplyReplaceAllChunked is the chunked version of ApplyReplaceAll.
+func (s *MutationStore[T]) ApplyReplaceAllChunked(ctx context.Context, client *spanner.Client, targets []T) ([]ChunkResult, error) {
+	return s.mutation.ApplyReplaceChunked(ctx, client, &targets)
+}
+
+// ApplyInsertOrUpdateAllChunked is the chunked version of ApplyInsertOrUpdateAll.
+func (s *MutationStore[T]) ApplyInsertOrUpdateAllChunked(ctx context.Context, client *spanner.Client, targets []T) ([]ChunkResult, error) {
+	return s.mutation.ApplyInsertOrUpdateChunked(ctx, client, &targets)
+}
+
+// ApplyUpdateAllChunked is the chunked version of ApplyUpdateAll.
+func (s *MutationStore[T]) ApplyUpdateAllChunked(ctx context.Context, client *spanner.Client, targets []T) ([]ChunkResult, error) {
+	return s.mutation.ApplyUpdateChunked(ctx, client, &targets)
+}
+
+// ApplyDeleteAllChunked is the chunked version of ApplyDeleteAll.
+func (s *MutationStore[T]) ApplyDeleteAllChunked(ctx context.Context, client *spanner.Client, targets []T) ([]ChunkResult, error) {
+	return s.mutation.ApplyDeleteChunked(ctx, client, &targets)
+}
+
+// With returns the copy of MutationStore which sends the requests with the passed options.
+// See Mutation.With for the details.
+func (s *MutationStore[T]) With(opts ...Option) *MutationStore[T] {
+	return newMutationStore[T](s.mutation.With(opts...))
+}
@@ -0,0 +1,67 @@
+package spnr
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testMutationStore = NewMutationStore[Test]("Test")
+	testDMLStore      = NewDMLStore[Test]("Test")
+)
+
+type testEmbeddedStore struct {
+	*DMLStore[Test]
+}
+
+func TestMutationStore(t *testing.T) {
+	ctx := context.Background()
+	_, err := testMutationStore.ApplyInsertOrUpdateAll(ctx, dataClient, []Test{*testRecord3, *testRecord4})
+	assert.Nil(t, err)
+
+	fetched, err := testMutationStore.FindOne(ctx, dataClient.Single(), spanner.Key{testRecord3.String, testRecord3.Int64})
+	assert.Nil(t, err)
+	assert.Equal(t, testRecord3.String, fetched.String)
+	assert.Equal(t, testRecord3.NullString, fetched.NullString)
+
+	keys := spanner.KeySetFromKeys(spanner.Key{testRecord3.String, testRecord3.Int64}, spanner.Key{testRecord4.String, testRecord4.Int64})
+	all, err := testMutationStore.FindAll(ctx, dataClient.Single(), keys)
+	assert.Nil(t, err)
+	assert.Len(t, all, 2)
+
+	queried, err := testMutationStore.Query(ctx, dataClient.Single(), "select * from Test order by `String` asc", nil)
+	assert.Nil(t, err)
+	assert.Len(t, queried, 2)
+	assert.Equal(t, testRecord3.String, queried[0].String)
+	assert.Equal(t, testRecord4.String, queried[1].String)
+
+	_, err = testMutationStore.ApplyDeleteAll(ctx, dataClient, []Test{*testRecord3, *testRecord4})
+	assert.Nil(t, err)
+	_, err = testMutationStore.FindOne(ctx, dataClient.Single(), spanner.Key{testRecord3.String, testRecord3.Int64})
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestDMLStore(t *testing.T) {
+	store := testEmbeddedStore{DMLStore: testDMLStore}
+	_, err := dataClient.ReadWriteTransaction(context.Background(), func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		_, err := store.Insert(ctx, tx, testRecord3)
+		assert.Nil(t, err)
+
+		updated := *testRecord3
+		updated.NullString = NewNullString("updated")
+		_, err = store.UpdateColumns(ctx, tx, []string{"NullString"}, &updated)
+		assert.Nil(t, err)
+
+		fetched, err := store.QueryOne(ctx, tx, "select * from Test where `String` = @string", map[string]any{"string": testRecord3.String})
+		assert.Nil(t, err)
+		assert.Equal(t, updated.NullString, fetched.NullString)
+
+		_, err = store.Delete(ctx, tx, testRecord3)
+		assert.Nil(t, err)
+		return nil
+	})
+	assert.Nil(t, err)
+}
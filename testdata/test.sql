@@ -22,4 +22,6 @@ CREATE TABLE Test (
 	ArrayBool ARRAY<BOOL>,
 	ArrayDate ARRAY<DATE>,
 	ArrayTimestamp ARRAY<TIMESTAMP>,
-) PRIMARY KEY (`String`, `Int64`)
+) PRIMARY KEY (`String`, `Int64`);
+
+CREATE INDEX TestByNullString ON Test(`NullString`) STORING (`Float64`)
@@ -0,0 +1,234 @@
+package spnr
+
+import (
+	"database/sql"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+)
+
+// TagErrorKind is the kind of the problem found in spnr tags.
+type TagErrorKind string
+
+const (
+	// TagErrInvalidPk means the pk tag is not an integer.
+	TagErrInvalidPk TagErrorKind = "invalid pk value"
+	// TagErrDuplicatePk means multiple fields have the same pk order.
+	TagErrDuplicatePk TagErrorKind = "duplicate pk order"
+	// TagErrNonContiguousPk means pk orders don't start from 1 or have gaps.
+	TagErrNonContiguousPk TagErrorKind = "non-contiguous pk order"
+	// TagErrPkWithoutColumn means the pk tag is set on a field without spanner tag.
+	TagErrPkWithoutColumn TagErrorKind = "pk tag on a field without spanner tag"
+	// TagErrDuplicateColumn means multiple fields are mapped to the same column.
+	TagErrDuplicateColumn TagErrorKind = "duplicate column"
+	// TagErrAmbiguousColumn means multiple fields of the embedded structs at the same depth are mapped to the same column.
+	TagErrAmbiguousColumn TagErrorKind = "ambiguous column"
+	// TagErrInvalidOption means the option in spanner tag is unknown or malformed.
+	TagErrInvalidOption TagErrorKind = "invalid tag option"
+	// TagErrUnsupportedType means the field type cannot be encoded to spanner.
+	TagErrUnsupportedType TagErrorKind = "unsupported field type"
+	// TagErrInvalidVersion means the version option is set on a field which cannot be used as the version.
+	// It's always reported, since ignoring it silently disables the optimistic locking.
+	TagErrInvalidVersion TagErrorKind = "invalid version option"
+)
+
+// TagError is a problem found in the tags of a struct field.
+type TagError struct {
+	Field  string
+	Kind   TagErrorKind
+	Detail string
+}
+
+func (e *TagError) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("field %s: %s", e.Field, e.Kind)
+	}
+	return fmt.Sprintf("field %s: %s (%s)", e.Field, e.Kind, e.Detail)
+}
+
+// fatal reports whether the problem makes the struct unusable even without strict validation.
+func (e *TagError) fatal() bool {
+	return e.Kind == TagErrInvalidPk || e.Kind == TagErrDuplicatePk || e.Kind == TagErrAmbiguousColumn || e.Kind == TagErrInvalidVersion
+}
+
+// ValidationError is returned when a struct has invalid spnr tags.
+// It contains all the problems found in the struct.
+type ValidationError struct {
+	Type   string
+	Errors []*TagError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, te := range e.Errors {
+		msgs = append(msgs, te.Error())
+	}
+	return fmt.Sprintf("invalid tags in %s: %s", e.Type, strings.Join(msgs, "; "))
+}
+
+// Validate checks the spnr tags of the passed struct and returns *ValidationError reporting all the problems found.
+// You can pass a struct, a pointer of struct or a slice of them.
+//
+// The tags are also checked lazily on the first use of each struct type.
+// By default only the problems that make the struct unusable (invalid or duplicate pk, invalid version option) are returned at that time.
+// Set Options.StrictValidation to report all of them.
+func Validate(target any) error {
+	tp, err := structTypeOf(target)
+	if err != nil {
+		return err
+	}
+	return getStructInfo(tp).validationErr(true)
+}
+
+// MustRegister validates the passed structs like Validate and caches their metadata.
+// It panics if any of the structs has invalid tags, so it's intended to be called on initialization.
+func MustRegister(targets ...any) {
+	for _, target := range targets {
+		if err := Validate(target); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (si *structInfo) validationErr(strict bool) error {
+	if si.err == nil {
+		return nil
+	}
+	if strict {
+		return si.err
+	}
+	var fatal []*TagError
+	for _, te := range si.err.Errors {
+		if te.fatal() {
+			fatal = append(fatal, te)
+		}
+	}
+	if len(fatal) == 0 {
+		return nil
+	}
+	return &ValidationError{Type: si.err.Type, Errors: fatal}
+}
+
+// validateTags checks the tags of the struct type passed as target of operations.
+func validateTags(target any, strict bool) error {
+	tp, err := structTypeOf(target)
+	if err != nil {
+		return err
+	}
+	return getStructInfo(tp).validationErr(strict)
+}
+
+// validateColumns returns an error if any of the columns is not mapped to the struct type of target.
+func validateColumns(target any, columns []string) error {
+	tp, err := structTypeOf(target)
+	if err != nil {
+		return err
+	}
+	si := getStructInfo(tp)
+	for _, c := range columns {
+		if _, ok := si.lookup(c); !ok {
+			return errors.Errorf("column %s is not mapped to any field of %s", c, tp)
+		}
+	}
+	return nil
+}
+
+func structTypeOf(target any) (reflect.Type, error) {
+	tp := reflect.TypeOf(target)
+	for tp != nil && (tp.Kind() == reflect.Ptr || tp.Kind() == reflect.Slice) {
+		tp = tp.Elem()
+	}
+	if tp == nil || tp.Kind() != reflect.Struct {
+		return nil, errors.Errorf("struct or slice of struct is required but got %T", target)
+	}
+	return tp, nil
+}
+
+func validatePkOrders(pks map[int][]string) []*TagError {
+	var orders []int
+	for order := range pks {
+		orders = append(orders, order)
+	}
+	sort.Ints(orders)
+
+	var errs []*TagError
+	for i, order := range orders {
+		if len(pks[order]) > 1 {
+			for _, name := range pks[order] {
+				errs = append(errs, &TagError{Field: name, Kind: TagErrDuplicatePk, Detail: fmt.Sprintf(`pk:"%d"`, order)})
+			}
+		}
+		if order != i+1 {
+			errs = append(errs, &TagError{Field: pks[order][0], Kind: TagErrNonContiguousPk, Detail: fmt.Sprintf(`expected pk:"%d" but got pk:"%d"`, i+1, order)})
+		}
+	}
+	return errs
+}
+
+var (
+	encoderType      = reflect.TypeOf((*spanner.Encoder)(nil)).Elem()
+	timeType         = reflect.TypeOf(time.Time{})
+	baseStructTypes  = []reflect.Type{reflect.TypeOf(time.Time{}), reflect.TypeOf(civil.Date{}), reflect.TypeOf(big.Rat{})}
+	supportedStructs = map[reflect.Type]bool{
+		reflect.TypeOf(time.Time{}):                  true,
+		reflect.TypeOf(civil.Date{}):                 true,
+		reflect.TypeOf(big.Rat{}):                    true,
+		reflect.TypeOf(spanner.NullString{}):         true,
+		reflect.TypeOf(spanner.NullInt64{}):          true,
+		reflect.TypeOf(spanner.NullBool{}):           true,
+		reflect.TypeOf(spanner.NullFloat64{}):        true,
+		reflect.TypeOf(spanner.NullNumeric{}):        true,
+		reflect.TypeOf(spanner.NullTime{}):           true,
+		reflect.TypeOf(spanner.NullDate{}):           true,
+		reflect.TypeOf(spanner.NullJSON{}):           true,
+		reflect.TypeOf(spanner.NullProtoMessage{}):   true,
+		reflect.TypeOf(spanner.NullProtoEnum{}):      true,
+		reflect.TypeOf(spanner.PGNumeric{}):          true,
+		reflect.TypeOf(spanner.PGJsonB{}):            true,
+		reflect.TypeOf(spanner.GenericColumnValue{}): true,
+		reflect.TypeOf(sql.NullString{}):             true,
+	}
+)
+
+// isSupportedType reports whether the value of the type can be encoded or decoded by the spanner client or spnr.
+func isSupportedType(tp reflect.Type) bool {
+	if tp.Implements(encoderType) || tp.Implements(protoMessageType) || isProtoEnum(tp) {
+		return true
+	}
+	switch tp.Kind() {
+	case reflect.String, reflect.Int, reflect.Int64, reflect.Bool, reflect.Float64:
+		return true
+	case reflect.Ptr:
+		// pointer is mapped from nullable column, but the spanner client doesn't support *int
+		el := tp.Elem()
+		return el.Kind() != reflect.Ptr && el.Kind() != reflect.Slice && el.Kind() != reflect.Int && isSupportedType(el)
+	case reflect.Slice:
+		el := tp.Elem()
+		if el.Kind() == reflect.Uint8 {
+			return true
+		}
+		if el.Kind() == reflect.Ptr {
+			el = el.Elem()
+		}
+		if el.Kind() == reflect.Struct {
+			// slice of any struct can be mapped from ARRAY<STRUCT>, but protobuf messages must be pointers
+			return !isProtoMessage(el) || tp.Elem().Kind() == reflect.Ptr
+		}
+		if el.Kind() == reflect.Slice && el.Elem().Kind() != reflect.Uint8 {
+			// spanner doesn't support nested arrays
+			return false
+		}
+		return isSupportedType(el)
+	case reflect.Struct:
+		// struct other than the spanner types is mapped from STRUCT using spanner tags
+		return !isProtoMessage(tp)
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package spnr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type invalidTags struct {
+	A   string         `spanner:"A" pk:"x"`
+	B   string         `spanner:"B" pk:"1"`
+	C   string         `spanner:"C" pk:"1"`
+	D   string         `spanner:"D" pk:"3"`
+	E   string         `spanner:"b"`
+	F   string         `pk:"4"`
+	G   map[string]int `spanner:"G"`
+	H   [][]int64      `spanner:"H"`
+	Ptr *string        `spanner:"Ptr"`
+	I   *int           `spanner:"I"`
+}
+
+type onlyNonFatalProblems struct {
+	A string `spanner:"A" pk:"2"`
+	B string `spanner:"a"`
+}
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, Validate(Test{}))
+	assert.Nil(t, Validate(&[]*Test{}))
+
+	err := Validate(&invalidTags{})
+	var vErr *ValidationError
+	assert.ErrorAs(t, err, &vErr)
+	assert.Equal(t, "spnr.invalidTags", vErr.Type)
+
+	kinds := map[string][]TagErrorKind{}
+	for _, te := range vErr.Errors {
+		kinds[te.Field] = append(kinds[te.Field], te.Kind)
+	}
+	assert.Equal(t, map[string][]TagErrorKind{
+		"A": {TagErrInvalidPk},
+		"B": {TagErrDuplicatePk},
+		"C": {TagErrDuplicatePk},
+		"D": {TagErrNonContiguousPk},
+		"E": {TagErrDuplicateColumn},
+		"F": {TagErrPkWithoutColumn},
+		"G": {TagErrUnsupportedType},
+		"H": {TagErrUnsupportedType},
+		"I": {TagErrUnsupportedType},
+	}, kinds)
+
+	assert.Panics(t, func() { MustRegister(Test{}, invalidTags{}) })
+	assert.NotNil(t, Validate("str"))
+}
+
+func TestValidateLazily(t *testing.T) {
+	err := validateTags(&invalidTags{}, false)
+	var vErr *ValidationError
+	assert.ErrorAs(t, err, &vErr)
+	for _, te := range vErr.Errors {
+		assert.Contains(t, []TagErrorKind{TagErrInvalidPk, TagErrDuplicatePk}, te.Kind)
+	}
+
+	assert.Nil(t, validateTags(&onlyNonFatalProblems{}, false))
+	assert.NotNil(t, validateTags(&onlyNonFatalProblems{}, true))
+
+	strictRepository := NewMutationWithOptions("Test", &Options{StrictValidation: true})
+	assert.NotNil(t, strictRepository.Update(nil, &onlyNonFatalProblems{}))
+	assert.NotNil(t, testRepository.Update(nil, &invalidTags{}))
+}
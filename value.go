@@ -0,0 +1,232 @@
+package spnr
+
+import (
+	"reflect"
+
+	"cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var decoderType = reflect.TypeOf((*spanner.Decoder)(nil)).Elem()
+
+// isNestedStruct reports whether the type is a struct mapped from STRUCT value using spanner tags,
+// rather than a struct the spanner client can decode by itself (e.g. time.Time, spanner.NullString).
+func isNestedStruct(tp reflect.Type) bool {
+	if tp.Kind() != reflect.Struct || supportedStructs[tp] || isProtoMessage(tp) {
+		return false
+	}
+	if tp.Implements(encoderType) || reflect.PointerTo(tp).Implements(decoderType) {
+		return false
+	}
+	for _, base := range baseStructTypes {
+		if tp.ConvertibleTo(base) {
+			return false
+		}
+	}
+	return true
+}
+
+// nestedStructOf returns the struct type if the type is a nested struct, a pointer of it, or a slice of them.
+func nestedStructOf(tp reflect.Type) (reflect.Type, bool) {
+	if tp.Kind() == reflect.Slice {
+		tp = tp.Elem()
+	}
+	if tp.Kind() == reflect.Ptr {
+		tp = tp.Elem()
+	}
+	return tp, isNestedStruct(tp)
+}
+
+// decodeRow maps the row into the passed pointer of struct.
+// Unlike spanner.Row.ToStruct, the columns are resolved by spnr tags, and STRUCT or ARRAY<STRUCT> columns are mapped into nested structs recursively.
+func decodeRow(row *spanner.Row, target any) error {
+	values := make([]spanner.GenericColumnValue, row.Size())
+	for i := range values {
+		if err := row.Column(i, &values[i]); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return decodeStruct(row.ColumnNames(), values, reflect.ValueOf(target).Elem())
+}
+
+func decodeStruct(names []string, values []spanner.GenericColumnValue, val reflect.Value) error {
+	si := getStructInfo(val.Type())
+	for i, name := range names {
+		f, ok := si.lookup(name)
+		if !ok {
+			f, ok = si.lookupUntagged(val.Type(), name)
+		}
+		if !ok {
+			return errors.Errorf("no field is mapped to column %s in %s", name, val.Type())
+		}
+		if err := decodeField(f, values[i], fieldByIndexAlloc(val, f)); err != nil {
+			return errors.Wrapf(err, "failed to decode column %s", name)
+		}
+	}
+	return nil
+}
+
+// decodeValue decodes the value into the field.
+// Nested structs are decoded by spnr tags, and the others are decoded by the spanner client.
+func decodeValue(gcv spanner.GenericColumnValue, fv reflect.Value) error {
+	tp := fv.Type()
+	if _, ok := nestedStructOf(tp); !ok {
+		if tp.Kind() == reflect.Ptr && (isProtoMessage(tp.Elem()) || isProtoEnum(tp.Elem())) {
+			return decodeProtoPtr(gcv, fv)
+		}
+		return gcv.Decode(fv.Addr().Interface())
+	}
+	if isNull(gcv) {
+		fv.Set(reflect.Zero(tp))
+		return nil
+	}
+
+	switch tp.Kind() {
+	case reflect.Slice:
+		if gcv.Type.GetCode() != sppb.TypeCode_ARRAY {
+			return errors.Errorf("cannot decode %s into %s", gcv.Type.GetCode(), tp)
+		}
+		elems := gcv.Value.GetListValue().GetValues()
+		slice := reflect.MakeSlice(tp, len(elems), len(elems))
+		for i, elem := range elems {
+			if err := decodeValue(spanner.GenericColumnValue{Type: gcv.Type.ArrayElementType, Value: elem}, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+		return nil
+	case reflect.Ptr:
+		e := reflect.New(tp.Elem())
+		if err := decodeValue(gcv, e.Elem()); err != nil {
+			return err
+		}
+		fv.Set(e)
+		return nil
+	default:
+		if gcv.Type.GetCode() != sppb.TypeCode_STRUCT {
+			return errors.Errorf("cannot decode %s into %s", gcv.Type.GetCode(), tp)
+		}
+		fields := gcv.Type.StructType.GetFields()
+		elems := gcv.Value.GetListValue().GetValues()
+		names := make([]string, len(fields))
+		values := make([]spanner.GenericColumnValue, len(fields))
+		for i, f := range fields {
+			names[i] = f.Name
+			values[i] = spanner.GenericColumnValue{Type: f.Type, Value: elems[i]}
+		}
+		return decodeStruct(names, values, fv)
+	}
+}
+
+func isNull(gcv spanner.GenericColumnValue) bool {
+	_, ok := gcv.Value.GetKind().(*structpb.Value_NullValue)
+	return ok
+}
+
+// encodeParams encodes the params of the types registered by RegisterType by their codecs,
+// and the nested structs into STRUCT values using spnr tags.
+// The other params are passed to the spanner client as they are.
+func encodeParams(params map[string]any) map[string]any {
+	var encoded map[string]any
+	for k, v := range params {
+		if v == nil {
+			continue
+		}
+		var value any
+		if f := (fieldInfo{name: k, typ: reflect.TypeOf(v)}); hasTypeCodec(f.typ) {
+			value = encodeField(f, reflect.ValueOf(v))
+		} else if _, ok := nestedStructOf(f.typ); ok {
+			gcv, err := encodeValue(reflect.ValueOf(v))
+			if err != nil {
+				// let the spanner client report the error
+				continue
+			}
+			value = gcv
+		} else {
+			continue
+		}
+		if encoded == nil {
+			encoded = make(map[string]any, len(params))
+			for k, v := range params {
+				encoded[k] = v
+			}
+		}
+		encoded[k] = value
+	}
+	if encoded == nil {
+		return params
+	}
+	return encoded
+}
+
+// encodeValue encodes the value into GenericColumnValue.
+// Nested structs are encoded into STRUCT values by spnr tags, and the others are encoded by the spanner client.
+func encodeValue(v reflect.Value) (spanner.GenericColumnValue, error) {
+	return encodeValueOnPath(v, map[reflect.Type]bool{})
+}
+
+// encodeValueOnPath is encodeValue which tracks the nested structs being encoded.
+// Recursive struct types cannot be encoded since the STRUCT type must be finite.
+func encodeValueOnPath(v reflect.Value, path map[reflect.Type]bool) (spanner.GenericColumnValue, error) {
+	tp := v.Type()
+	if _, ok := nestedStructOf(tp); !ok {
+		var gcv spanner.GenericColumnValue
+		row, err := spanner.NewRow([]string{""}, []any{v.Interface()})
+		if err != nil {
+			return gcv, errors.WithStack(err)
+		}
+		return gcv, errors.WithStack(row.Column(0, &gcv))
+	}
+
+	switch tp.Kind() {
+	case reflect.Slice:
+		elemType, err := encodeValueOnPath(reflect.Zero(tp.Elem()), path)
+		if err != nil {
+			return spanner.GenericColumnValue{}, err
+		}
+		arrayType := &sppb.Type{Code: sppb.TypeCode_ARRAY, ArrayElementType: elemType.Type}
+		if v.IsNil() {
+			return spanner.GenericColumnValue{Type: arrayType, Value: structpb.NewNullValue()}, nil
+		}
+		elems := make([]*structpb.Value, v.Len())
+		for i := range elems {
+			elem, err := encodeValueOnPath(v.Index(i), path)
+			if err != nil {
+				return spanner.GenericColumnValue{}, err
+			}
+			elems[i] = elem.Value
+		}
+		return spanner.GenericColumnValue{Type: arrayType, Value: structpb.NewListValue(&structpb.ListValue{Values: elems})}, nil
+	case reflect.Ptr:
+		if v.IsNil() {
+			gcv, err := encodeValueOnPath(reflect.Zero(tp.Elem()), path)
+			return spanner.GenericColumnValue{Type: gcv.Type, Value: structpb.NewNullValue()}, err
+		}
+		return encodeValueOnPath(v.Elem(), path)
+	default:
+		if path[tp] {
+			return spanner.GenericColumnValue{}, errors.Errorf("recursive struct %s cannot be encoded", tp)
+		}
+		path[tp] = true
+		defer delete(path, tp)
+		si := getStructInfo(tp)
+		fields := make([]*sppb.StructType_Field, len(si.fields))
+		elems := make([]*structpb.Value, len(si.fields))
+		for i, f := range si.fields {
+			fv := fieldByIndex(v, f)
+			if c, _, _ := codecOf(f); c != nil || f.opts.codec != "" {
+				fv = reflect.ValueOf(encodeField(f, fv))
+			}
+			gcv, err := encodeValueOnPath(fv, path)
+			if err != nil {
+				return spanner.GenericColumnValue{}, errors.Wrapf(err, "failed to encode field %s", f.name)
+			}
+			fields[i] = &sppb.StructType_Field{Name: f.name, Type: gcv.Type}
+			elems[i] = gcv.Value
+		}
+		structType := &sppb.Type{Code: sppb.TypeCode_STRUCT, StructType: &sppb.StructType{Fields: fields}}
+		return spanner.GenericColumnValue{Type: structType, Value: structpb.NewListValue(&structpb.ListValue{Values: elems})}, nil
+	}
+}
@@ -0,0 +1,154 @@
+package spnr
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/stretchr/testify/assert"
+)
+
+type testItem struct {
+	Name  string             `spanner:"Name"`
+	Count int64              `spanner:"Count"`
+	Tags  []string           `spanner:"Tags"`
+	Sub   *testSubItem       `spanner:"Sub"`
+	Note  spanner.NullString `spanner:"Note"`
+	Skip  string
+}
+
+type testSubItem struct {
+	Name string `spanner:"Name"`
+}
+
+type testOrder struct {
+	ID     string      `spanner:"ID"`
+	Items  []testItem  `spanner:"Items"`
+	PItems []*testItem `spanner:"PItems"`
+}
+
+func TestIsNestedStruct(t *testing.T) {
+	assert.True(t, isNestedStruct(reflect.TypeOf(testItem{})))
+	assert.False(t, isNestedStruct(reflect.TypeOf(spanner.NullString{})))
+	assert.False(t, isNestedStruct(reflect.TypeOf(testRecord1.Timestamp)))
+	assert.False(t, isNestedStruct(reflect.TypeOf("")))
+
+	_, ok := nestedStructOf(reflect.TypeOf([]*testItem{}))
+	assert.True(t, ok)
+	_, ok = nestedStructOf(reflect.TypeOf([]string{}))
+	assert.False(t, ok)
+}
+
+func TestEncodeDecodeValue(t *testing.T) {
+	item := testItem{Name: "a", Count: 1, Tags: []string{"x"}, Sub: &testSubItem{Name: "b"}, Note: spanner.NullString{StringVal: "n", Valid: true}, Skip: "skip"}
+
+	gcv, err := encodeValue(reflect.ValueOf(item))
+	assert.Nil(t, err)
+	assert.Equal(t, sppb.TypeCode_STRUCT, gcv.Type.Code)
+	var names []string
+	for _, f := range gcv.Type.StructType.Fields {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"Name", "Count", "Tags", "Sub", "Note"}, names)
+
+	var decoded testItem
+	assert.Nil(t, decodeValue(gcv, reflect.ValueOf(&decoded).Elem()))
+	item.Skip = ""
+	assert.Equal(t, item, decoded)
+
+	type recursive struct {
+		Child *recursive `spanner:"Child"`
+	}
+	_, err = encodeValue(reflect.ValueOf(recursive{}))
+	assert.NotNil(t, err)
+
+	// slice including nil
+	items := []*testItem{{Name: "c"}, nil}
+	gcv, err = encodeValue(reflect.ValueOf(items))
+	assert.Nil(t, err)
+	assert.Equal(t, sppb.TypeCode_ARRAY, gcv.Type.Code)
+	var decodedItems []*testItem
+	assert.Nil(t, decodeValue(gcv, reflect.ValueOf(&decodedItems).Elem()))
+	assert.Len(t, decodedItems, 2)
+	assert.Equal(t, "c", decodedItems[0].Name)
+	assert.Nil(t, decodedItems[1])
+
+	// null
+	gcv, err = encodeValue(reflect.ValueOf([]testItem(nil)))
+	assert.Nil(t, err)
+	decodedItems = []*testItem{{}}
+	assert.Nil(t, decodeValue(gcv, reflect.ValueOf(&decodedItems).Elem()))
+	assert.Nil(t, decodedItems)
+}
+
+func TestDecodeRow(t *testing.T) {
+	items, err := encodeValue(reflect.ValueOf([]testItem{{Name: "a", Count: 1}}))
+	assert.Nil(t, err)
+	row, err := spanner.NewRow([]string{"ID", "Items"}, []any{"id", items})
+	assert.Nil(t, err)
+
+	var order testOrder
+	assert.Nil(t, decodeRow(row, &order))
+	assert.Equal(t, testOrder{ID: "id", Items: []testItem{{Name: "a", Count: 1}}}, order)
+
+	row, err = spanner.NewRow([]string{"Unknown"}, []any{"x"})
+	assert.Nil(t, err)
+	assert.NotNil(t, decodeRow(row, &order))
+}
+
+func TestDecodeRowUntagged(t *testing.T) {
+	// the untagged fields are mapped by the field names like spanner.Row.ToStruct
+	type embedded struct {
+		Total int64
+	}
+	type aggregate struct {
+		embedded
+		Name    string
+		Count   int64  `spanner:"Cnt"`
+		Ignored string `spanner:"-"`
+		private string
+	}
+	row, err := spanner.NewRow([]string{"name", "Cnt", "Total"}, []any{"a", int64(2), int64(3)})
+	assert.Nil(t, err)
+	var agg aggregate
+	assert.Nil(t, decodeRow(row, &agg))
+	assert.Equal(t, aggregate{embedded: embedded{Total: 3}, Name: "a", Count: 2}, agg)
+
+	for _, column := range []string{"Count", "Ignored", "private"} {
+		row, err = spanner.NewRow([]string{column}, []any{"x"})
+		assert.Nil(t, err)
+		assert.ErrorContains(t, decodeRow(row, &agg), "no field is mapped to column "+column)
+	}
+}
+
+func TestEncodeParams(t *testing.T) {
+	params := map[string]any{"id": "a"}
+	assert.Equal(t, params, encodeParams(params))
+	assert.Nil(t, encodeParams(nil))
+
+	encoded := encodeParams(map[string]any{"id": "a", "item": testItem{Name: "a"}, "items": []*testItem{}})
+	assert.Equal(t, "a", encoded["id"])
+	assert.IsType(t, spanner.GenericColumnValue{}, encoded["item"])
+	assert.IsType(t, spanner.GenericColumnValue{}, encoded["items"])
+
+	// the types registered by RegisterType are encoded by their codecs
+	interval := 2 * time.Second
+	encoded = encodeParams(map[string]any{"timeout": 3 * time.Second, "interval": &interval})
+	assert.Equal(t, int64(3000), encoded["timeout"])
+	assert.Equal(t, int64(2000), encoded["interval"])
+}
+
+func TestQueryNestedStruct(t *testing.T) {
+	ctx := context.Background()
+	var order testOrder
+	err := testRepository.Reader(ctx, dataClient.Single()).QueryOne(
+		"SELECT 'id' AS ID, ARRAY(SELECT AS STRUCT @item.Name AS Name, @item.Count AS Count, @item.Tags AS Tags) AS Items",
+		map[string]any{"item": testItem{Name: "a", Count: 2, Tags: []string{"x"}}},
+		&order,
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, testOrder{ID: "id", Items: []testItem{{Name: "a", Count: 2, Tags: []string{"x"}}}}, order)
+}
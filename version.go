@@ -0,0 +1,151 @@
+package spnr
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/pkg/errors"
+)
+
+// ErrStaleVersion is returned by the updates of the struct with version option (e.g. `spanner:"Version,version"`)
+// when the version of the record doesn't match the one of the struct.
+// It means the record was updated or deleted by others after it was read.
+var ErrStaleVersion = errors.New("stale version")
+
+// versionOf returns the version field of the struct type of the targets.
+func versionOf(targets []any) (fieldInfo, bool) {
+	if len(targets) == 0 {
+		return fieldInfo{}, false
+	}
+	si := getStructInfo(reflect.TypeOf(targets[0]).Elem())
+	if si.version == nil {
+		return fieldInfo{}, false
+	}
+	return *si.version, true
+}
+
+// checkRowCounts returns ErrStaleVersion if the struct has version option and any of the statements updated no records.
+func checkRowCounts(target any, rowCounts ...int64) error {
+	tp := reflect.TypeOf(target).Elem()
+	if tp.Kind() == reflect.Slice {
+		tp = tp.Elem()
+		if tp.Kind() == reflect.Ptr {
+			tp = tp.Elem()
+		}
+	}
+	if getStructInfo(tp).version == nil {
+		return nil
+	}
+	for _, c := range rowCounts {
+		if c == 0 {
+			return ErrStaleVersion
+		}
+	}
+	return nil
+}
+
+// checkVersions reads the current versions of the records in the transaction with one read,
+// and returns ErrStaleVersion if any of them doesn't match the version of the struct, the version is NULL or the record doesn't exist.
+func (m *Mutation) checkVersions(ctx context.Context, tx *spanner.ReadWriteTransaction, targets []any) error {
+	f, ok := versionOf(targets)
+	if !ok {
+		return nil
+	}
+	tp := reflect.TypeOf(targets[0]).Elem()
+	columns, keys := keyColumns(tp, f.name, targets)
+	current, err := m.readLastColumn(ctx, tx, tp, columns, keys)
+	if err != nil {
+		return err
+	}
+	for i, target := range targets {
+		gcv, ok := current[keys[i].String()]
+		if !ok {
+			return ErrStaleVersion
+		}
+		var v spanner.NullInt64
+		if err := gcv.Decode(&v); err != nil {
+			return errors.WithStack(err)
+		}
+		if !v.Valid {
+			return errors.Wrapf(ErrStaleVersion, "version column %s of %v is NULL", f.name, keys[i])
+		}
+		if v.Int64 != fieldByIndex(reflect.ValueOf(target).Elem(), f).Int() {
+			return ErrStaleVersion
+		}
+	}
+	return nil
+}
+
+// readLastColumn reads the last column of columns (the primary key columns followed by a column) of the records,
+// and returns the values by the string of the primary keys.
+func (m *Mutation) readLastColumn(ctx context.Context, tx *spanner.ReadWriteTransaction, tp reflect.Type, columns []string, keys []spanner.Key) (map[string]spanner.GenericColumnValue, error) {
+	n := len(columns) - 1
+	found := make(map[string]spanner.GenericColumnValue, len(keys))
+	rows := tx.ReadWithOptions(ctx, m.table, spanner.KeySetFromKeys(keys...), columns, m.opts.readOptions(""))
+	err := rows.Do(func(row *spanner.Row) error {
+		values := make([]spanner.GenericColumnValue, row.Size())
+		for i := range values {
+			if err := row.Column(i, &values[i]); err != nil {
+				return err
+			}
+		}
+		// the primary keys are decoded into the struct to build the key in the same way as the targets
+		val := reflect.New(tp)
+		if err := decodeStruct(columns[:n], values[:n], val.Elem()); err != nil {
+			return err
+		}
+		found[toKey(val).String()] = values[n]
+		return nil
+	})
+	return found, errors.WithStack(err)
+}
+
+// keyColumns returns the primary key columns of the struct followed by the column, and the primary keys of the targets.
+func keyColumns(tp reflect.Type, column string, targets []any) ([]string, []spanner.Key) {
+	si := getStructInfo(tp)
+	columns := make([]string, 0, len(si.pks)+1)
+	for _, pk := range si.pks {
+		columns = append(columns, pk.name)
+	}
+	columns = append(columns, column)
+	keys := make([]spanner.Key, 0, len(targets))
+	for _, target := range targets {
+		keys = append(keys, toKey(reflect.ValueOf(target)))
+	}
+	return columns, keys
+}
+
+// incrementVersion sets the incremented version to the values written by the update mutation.
+// The version column is added if it's not in the columns.
+func incrementVersion(target any, columns []string, values []any) ([]string, []any) {
+	f, ok := versionOf([]any{target})
+	if !ok {
+		return columns, values
+	}
+	next := fieldByIndex(reflect.ValueOf(target).Elem(), f).Int() + 1
+	for i, c := range columns {
+		if strings.EqualFold(c, f.name) {
+			values[i] = next
+			return columns, values
+		}
+	}
+	return append(columns[:len(columns):len(columns)], f.name), append(values, next)
+}
+
+// applyUpdate applies the update mutations built by build.
+// If the struct has version option, the versions are checked and the mutations are written in a read-write transaction.
+func (m *Mutation) applyUpdate(ctx context.Context, client *spanner.Client, targets []any, build func([]any) []*spanner.Mutation) (time.Time, error) {
+	if _, ok := versionOf(targets); !ok {
+		return m.apply(ctx, client, build(targets))
+	}
+	resp, err := client.ReadWriteTransactionWithOptions(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		if err := m.checkVersions(ctx, tx, targets); err != nil {
+			return err
+		}
+		return tx.BufferWrite(build(targets))
+	}, m.opts.transactionOptions())
+	return resp.CommitTs, err
+}